@@ -0,0 +1,234 @@
+package xlsy
+
+import (
+	"fmt"
+	"github.com/xuri/excelize/v2"
+	"strings"
+)
+
+// borderSides represents excelize border types in css order
+var borderSides = []string{"top", "right", "bottom", "left"}
+
+// parseBorder parses css like border definition (i.e. "1px solid #ccc") into excelize border style and color,
+// zero style (none, hidden or zero width) removes a border
+func parseBorder(value string) (int, string, error) {
+	width, kind, color := 1.0, "solid", ""
+	for _, item := range strings.Fields(value) {
+		switch item = strings.ToLower(item); item {
+		case "none", "hidden":
+			return 0, "", nil
+		case "solid", "dashed", "dotted", "double", "hairline", "dash-dot", "dash-dot-dot":
+			kind = item
+		case "thin":
+			width = 1
+		case "medium":
+			width = 2
+		case "thick":
+			width = 3
+		default:
+			if length, err := parseLength(item); err == nil {
+				width = length.Size
+				continue
+			}
+			var err error
+			if color, err = ensureColor(item); err != nil {
+				return 0, "", fmt.Errorf("invalid border: %v, %w", value, err)
+			}
+		}
+	}
+	if width <= 0 {
+		return 0, "", nil
+	}
+	switch kind {
+	case "dashed":
+		if width >= 2 {
+			return 8, color, nil
+		}
+		return 3, color, nil
+	case "dotted":
+		return 4, color, nil
+	case "double":
+		return 6, color, nil
+	case "hairline":
+		return 7, color, nil
+	case "dash-dot":
+		if width >= 2 {
+			return 10, color, nil
+		}
+		return 9, color, nil
+	case "dash-dot-dot":
+		if width >= 2 {
+			return 12, color, nil
+		}
+		return 11, color, nil
+	}
+	switch {
+	case width >= 3:
+		return 5, color, nil
+	case width >= 2:
+		return 2, color, nil
+	}
+	return 1, color, nil
+}
+
+func (s *Style) updateBorder(value string, style *extStyle, sides ...string) error {
+	borderStyle, color, err := parseBorder(value)
+	if err != nil {
+		return err
+	}
+	style.ensureStyle()
+	for _, side := range sides {
+		style.Border = setBorder(style.Border, excelize.Border{Type: side, Style: borderStyle, Color: color})
+	}
+	return nil
+}
+
+// setBorder replaces border side, zero style border is removed
+func setBorder(borders []excelize.Border, border excelize.Border) []excelize.Border {
+	var ret []excelize.Border
+	for _, candidate := range borders {
+		if candidate.Type != border.Type {
+			ret = append(ret, candidate)
+		}
+	}
+	if border.Style > 0 {
+		ret = append(ret, border)
+	}
+	return ret
+}
+
+func hasBorder(borders []excelize.Border, side string) bool {
+	for _, candidate := range borders {
+		if candidate.Type == side {
+			return true
+		}
+	}
+	return false
+}
+
+// borderCSS returns css border definition for excelize border style
+func borderCSS(border excelize.Border) string {
+	var ret string
+	switch border.Style {
+	case 2:
+		ret = "2px solid"
+	case 3, 9, 11, 13:
+		ret = "1px dashed"
+	case 4:
+		ret = "1px dotted"
+	case 5:
+		ret = "3px solid"
+	case 6:
+		ret = "3px double"
+	case 8, 10, 12:
+		ret = "2px dashed"
+	default:
+		ret = "1px solid"
+	}
+	color := border.Color
+	if color == "" {
+		color = "#000000"
+	}
+	return ret + " " + color
+}
+
+// tableBorder represents table outer box and inner grid borders
+type tableBorder struct {
+	box   excelize.Border
+	grid  excelize.Border
+	ids   map[int]map[int]int //style ID by border sides mask by cell style ID
+	table *Table
+}
+
+func newTableBorder(table *Table) (*tableBorder, error) {
+	ret := &tableBorder{table: table, ids: map[int]map[int]int{}}
+	var err error
+	if table.Tag.Box != "" {
+		if ret.box.Style, ret.box.Color, err = parseBorder(table.Tag.Box); err != nil {
+			return nil, err
+		}
+	}
+	if table.Tag.Grid != "" {
+		if ret.grid.Style, ret.grid.Color, err = parseBorder(table.Tag.Grid); err != nil {
+			return nil, err
+		}
+	}
+	return ret, nil
+}
+
+// drawTableBorder draws table box and grid over [begin, end] cells range, cell borders defined by styles take precedence
+func (s *workSheet) drawTableBorder(table *Table, begin, end Cursor) error {
+	if table.Tag.Box == "" && table.Tag.Grid == "" {
+		return nil
+	}
+	border, err := newTableBorder(table)
+	if err != nil {
+		return err
+	}
+	for row := begin.row(); row <= end.row(); row++ {
+		for column := begin.column(); column <= end.column(); column++ {
+			mask := 0
+			for i, outer := range []bool{row == begin.row(), column == end.column(), row == end.row(), column == begin.column()} {
+				if outer {
+					mask |= 1 << i
+				}
+			}
+			cell := newCursor(row, column).String()
+			styleID, err := s.GetCellStyle(cell)
+			if err != nil {
+				return err
+			}
+			if styleID, err = border.styleID(s.dest, styleID, mask); err != nil {
+				return err
+			}
+			if err = s.SetCellStyle(cell, cell, styleID); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// styleID returns cell style ID extended with box borders on outer sides mask and grid borders on inner sides
+func (b *tableBorder) styleID(file *excelize.File, styleID int, mask int) (int, error) {
+	if id, ok := b.ids[styleID][mask]; ok {
+		return id, nil
+	}
+	var style excelize.Style
+	derived := &extStyle{}
+	if registered := b.table.Stylizer.extStyle(styleID); registered != nil && registered.Style != nil {
+		*derived = *registered
+		style = *registered.Style
+	} else {
+		fileStyle, err := file.GetStyle(styleID)
+		if err != nil {
+			return 0, err
+		}
+		style = *fileStyle
+	}
+	borders := append([]excelize.Border{}, style.Border...)
+	for i, side := range borderSides {
+		if hasBorder(borders, side) {
+			continue
+		}
+		border := b.grid
+		if mask&(1<<i) != 0 {
+			border = b.box
+		}
+		border.Type = side
+		borders = setBorder(borders, border)
+	}
+	style.Border = borders
+	id, err := file.NewStyle(&style)
+	if err != nil {
+		return 0, err
+	}
+	derived.ID = &id
+	derived.Style = &style
+	b.table.Stylizer.derive(id, derived)
+	if b.ids[styleID] == nil {
+		b.ids[styleID] = map[int]int{}
+	}
+	b.ids[styleID][mask] = id
+	return id, nil
+}
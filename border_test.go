@@ -0,0 +1,180 @@
+package xlsy
+
+import (
+	"bytes"
+	"github.com/stretchr/testify/assert"
+	"github.com/xuri/excelize/v2"
+	"testing"
+)
+
+func TestStyle_updateBorder(t *testing.T) {
+	var testCases = []struct {
+		description string
+		definition  string
+		expect      []excelize.Border
+		hasError    bool
+	}{
+		{
+			description: "all sides",
+			definition:  "border:1px solid #ccc",
+			expect: []excelize.Border{
+				{Type: "top", Style: 1, Color: "#cccccc"},
+				{Type: "right", Style: 1, Color: "#cccccc"},
+				{Type: "bottom", Style: 1, Color: "#cccccc"},
+				{Type: "left", Style: 1, Color: "#cccccc"},
+			},
+		},
+		{
+			description: "side override",
+			definition:  "border:thin dotted;border-bottom:2px double red;border-left:none",
+			expect: []excelize.Border{
+				{Type: "top", Style: 4},
+				{Type: "right", Style: 4},
+				{Type: "bottom", Style: 6, Color: "#ff0000"},
+			},
+		},
+		{
+			description: "width mapping",
+			definition:  "border-top:3px solid;border-right:2px dashed;border-bottom:medium dash-dot;border-left:1px dash-dot-dot blue",
+			expect: []excelize.Border{
+				{Type: "top", Style: 5},
+				{Type: "right", Style: 8},
+				{Type: "bottom", Style: 10},
+				{Type: "left", Style: 11, Color: "#0000ff"},
+			},
+		},
+		{
+			description: "zero width",
+			definition:  "border:0",
+		},
+		{
+			description: "zero width side",
+			definition:  "border:1px solid;border-left:0px",
+			expect: []excelize.Border{
+				{Type: "top", Style: 1},
+				{Type: "right", Style: 1},
+				{Type: "bottom", Style: 1},
+			},
+		},
+		{
+			description: "invalid border",
+			definition:  "border:1px groovy",
+			hasError:    true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		style := &Style{Definition: testCase.definition}
+		err := style.Init()
+		if testCase.hasError {
+			assert.NotNil(t, err, testCase.description)
+			continue
+		}
+		if !assert.Nil(t, err, testCase.description) {
+			continue
+		}
+		assert.EqualValues(t, testCase.expect, style.Cell.Border, testCase.description)
+	}
+}
+
+func TestMarshaller_Marshal_border(t *testing.T) {
+	type Record struct {
+		ID   int    `xls:"name=Id"`
+		Name string `xls:"name=Name,style={border-right:2px dashed red}"`
+	}
+	type Report struct {
+		Records []Record `xls:"box={2px solid #000},grid={1px solid #ccc}"`
+	}
+	records := []Record{{ID: 1, Name: "a"}, {ID: 2, Name: "b"}}
+
+	var testCases = []struct {
+		description string
+		options     []Option
+		source      interface{}
+		expect      map[string]map[string]excelize.Border
+		hasError    bool
+	}{
+		{
+			description: "table border option",
+			options:     []Option{WithTableBorder("2px solid #000", "1px solid #ccc")},
+			source:      records,
+			expect: map[string]map[string]excelize.Border{
+				"A1": {
+					"top":    {Type: "top", Style: 2, Color: "000000"},
+					"left":   {Type: "left", Style: 2, Color: "000000"},
+					"right":  {Type: "right", Style: 1, Color: "CCCCCC"},
+					"bottom": {Type: "bottom", Style: 1, Color: "CCCCCC"},
+				},
+				"B2": {
+					"top":    {Type: "top", Style: 1, Color: "CCCCCC"},
+					"left":   {Type: "left", Style: 1, Color: "CCCCCC"},
+					"right":  {Type: "right", Style: 8, Color: "FF0000"},
+					"bottom": {Type: "bottom", Style: 1, Color: "CCCCCC"},
+				},
+				"A3": {
+					"top":    {Type: "top", Style: 1, Color: "CCCCCC"},
+					"left":   {Type: "left", Style: 2, Color: "000000"},
+					"right":  {Type: "right", Style: 1, Color: "CCCCCC"},
+					"bottom": {Type: "bottom", Style: 2, Color: "000000"},
+				},
+			},
+		},
+		{
+			description: "table border tag",
+			source:      &Report{Records: records},
+			expect: map[string]map[string]excelize.Border{
+				"B3": {
+					"top":    {Type: "top", Style: 1, Color: "CCCCCC"},
+					"left":   {Type: "left", Style: 1, Color: "CCCCCC"},
+					"right":  {Type: "right", Style: 8, Color: "FF0000"},
+					"bottom": {Type: "bottom", Style: 2, Color: "000000"},
+				},
+			},
+		},
+		{
+			description: "streamed table border",
+			options:     []Option{WithTableBorder("2px solid #000", ""), WithStreaming()},
+			source:      records,
+			hasError:    true,
+		},
+		{
+			description: "streamed cell border",
+			options:     []Option{WithStreaming()},
+			source:      records,
+			expect: map[string]map[string]excelize.Border{
+				"B2": {"right": {Type: "right", Style: 8, Color: "FF0000"}},
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		marshaller := NewMarshaller(testCase.options...)
+		data, err := marshaller.Marshal(testCase.source)
+		if testCase.hasError {
+			assert.NotNil(t, err, testCase.description)
+			continue
+		}
+		if !assert.Nil(t, err, testCase.description) {
+			continue
+		}
+		file, err := excelize.OpenReader(bytes.NewReader(data))
+		if !assert.Nil(t, err, testCase.description) {
+			continue
+		}
+		sheet := file.GetSheetName(0)
+		for cell, expect := range testCase.expect {
+			styleID, err := file.GetCellStyle(sheet, cell)
+			assert.Nil(t, err, testCase.description)
+			style, err := file.GetStyle(styleID)
+			if !assert.Nil(t, err, testCase.description) {
+				continue
+			}
+			actual := map[string]excelize.Border{}
+			for _, border := range style.Border {
+				actual[border.Type] = border
+			}
+			assert.EqualValues(t, expect, actual, testCase.description+" "+cell)
+		}
+		_ = file.Close()
+	}
+}
@@ -0,0 +1,191 @@
+package xlsy
+
+import (
+	"fmt"
+	"github.com/xuri/excelize/v2"
+	"strings"
+)
+
+// comparisonOperators represents supported cell value comparison criteria, longer operators first
+var comparisonOperators = []string{"<=", ">=", "<>", "!=", "==", "<", ">"}
+
+// iconSets represents supported icon set styles
+var iconSets = []string{"3Arrows", "3ArrowsGray", "3Flags", "3Signs", "3Symbols", "3Symbols2", "3TrafficLights1", "3TrafficLights2",
+	"4Arrows", "4ArrowsGray", "4Rating", "4RedToBlack", "4TrafficLights", "5Arrows", "5ArrowsGray", "5Quarters", "5Rating"}
+
+func (s *Style) updateScale(value string) (err error) {
+	if s.Scale, err = ensureColors(strings.Join(strings.Fields(value), " ")); err != nil {
+		return fmt.Errorf("invalid scale: %w, %v", err, value)
+	}
+	if len(s.Scale) < 2 || len(s.Scale) > 3 {
+		return fmt.Errorf("invalid scale: %v, expected 2 or 3 colors", value)
+	}
+	return nil
+}
+
+func (s *Style) updateDataBar(value string) (err error) {
+	if s.DataBar, err = ensureColor(strings.TrimSpace(value)); err != nil {
+		return fmt.Errorf("invalid data-bar: %w, %v", err, value)
+	}
+	return nil
+}
+
+func (s *Style) updateIconSet(value string) error {
+	for _, candidate := range iconSets {
+		if strings.EqualFold(candidate, strings.TrimSpace(value)) {
+			s.IconSet = candidate
+			return nil
+		}
+	}
+	return fmt.Errorf("unsupported icon-set: %v", value)
+}
+
+// rangeFormats returns color scale, data bar and icon set conditional formats
+func (s *Style) rangeFormats() []excelize.ConditionalFormatOptions {
+	var ret []excelize.ConditionalFormatOptions
+	switch len(s.Scale) {
+	case 2:
+		ret = append(ret, excelize.ConditionalFormatOptions{Type: "2_color_scale", Criteria: "=",
+			MinType: "min", MaxType: "max", MinColor: s.Scale[0], MaxColor: s.Scale[1]})
+	case 3:
+		ret = append(ret, excelize.ConditionalFormatOptions{Type: "3_color_scale", Criteria: "=",
+			MinType: "min", MidType: "percentile", MidValue: "50", MaxType: "max", MinColor: s.Scale[0], MidColor: s.Scale[1], MaxColor: s.Scale[2]})
+	}
+	if s.DataBar != "" {
+		ret = append(ret, excelize.ConditionalFormatOptions{Type: "data_bar", Criteria: "=", MinType: "min", MaxType: "max", BarColor: s.DataBar})
+	}
+	if s.IconSet != "" {
+		ret = append(ret, excelize.ConditionalFormatOptions{Type: "icon_set", IconStyle: s.IconSet})
+	}
+	return ret
+}
+
+// parseCondition parses cond style when criteria: comparison (i.e. "<0"), range ("between 1 and 10") or formula ("=$C2>TODAY()")
+func parseCondition(when string) (*excelize.ConditionalFormatOptions, error) {
+	when = strings.TrimSpace(when)
+	if when == "" {
+		return nil, fmt.Errorf("missing cond style when criteria")
+	}
+	if strings.HasPrefix(when, "=") && !strings.HasPrefix(when, "==") {
+		return &excelize.ConditionalFormatOptions{Type: "formula", Criteria: strings.TrimSpace(when[1:])}, nil
+	}
+	lower := strings.ToLower(when)
+	for _, criteria := range []string{"not between", "between"} {
+		if !strings.HasPrefix(lower, criteria+" ") {
+			continue
+		}
+		bounds := when[len(criteria):]
+		index := strings.Index(strings.ToLower(bounds), " and ")
+		if index == -1 {
+			return nil, fmt.Errorf("invalid cond style when: %v, expected %v <min> and <max>", when, criteria)
+		}
+		return &excelize.ConditionalFormatOptions{Type: "cell", Criteria: criteria,
+			MinValue: strings.TrimSpace(bounds[:index]), MaxValue: strings.TrimSpace(bounds[index+len(" and "):])}, nil
+	}
+	for _, operator := range comparisonOperators {
+		if !strings.HasPrefix(when, operator) {
+			continue
+		}
+		value := strings.TrimSpace(when[len(operator):])
+		if value == "" {
+			return nil, fmt.Errorf("invalid cond style when: %v, missing value", when)
+		}
+		return &excelize.ConditionalFormatOptions{Type: "cell", Criteria: operator, Value: value}, nil
+	}
+	return nil, fmt.Errorf("unsupported cond style when: %v", when)
+}
+
+// conditionalFormat returns conditional format options for cond style definition, format style is registered once
+func (s *Stylizer) conditionalFormat(definition string) (*excelize.ConditionalFormatOptions, error) {
+	if ret, ok := s.conditions[definition]; ok {
+		return ret, nil
+	}
+	style := &Style{Definition: definition}
+	if err := style.Init(); err != nil {
+		return nil, err
+	}
+	ret, err := parseCondition(style.When)
+	if err != nil {
+		return nil, err
+	}
+	if style.Cell.Style == nil {
+		return nil, fmt.Errorf("invalid cond style: %v, missing format", definition)
+	}
+	if ret.Format, err = s.file.NewConditionalStyle(style.Cell.Style); err != nil {
+		return nil, err
+	}
+	if s.conditions == nil {
+		s.conditions = map[string]*excelize.ConditionalFormatOptions{}
+	}
+	s.conditions[definition] = ret
+	return ret, nil
+}
+
+// applyConditions registers columns conditional formats, color scales, data bars and icon sets over [begin, end] table data range,
+// nested tables with the same orientation are included
+func (s *workSheet) applyConditions(table *Table, begin, end Cursor) error {
+	return s.forEachDataRange(table, begin, end, func(column *Column, rangeRef string) error {
+		options, err := column.conditionalFormats(table.Stylizer)
+		if err != nil || len(options) == 0 {
+			return err
+		}
+		return s.dest.SetConditionalFormat(s.name, rangeRef, options)
+	})
+}
+
+// forEachDataRange calls fn with each column data range within [begin, end] table data range,
+// nested tables with the same orientation are included
+func (s *workSheet) forEachDataRange(table *Table, begin, end Cursor, fn func(column *Column, rangeRef string) error) error {
+	if table.Header == nil || end.value(table.UseRow(true)) < begin.value(table.UseRow(true)) {
+		return nil
+	}
+	for i, header := range table.Header.Values {
+		if header.snapshot == nil {
+			continue
+		}
+		column := table.columnByIndex(i)
+		if colTable := column.Table; colTable != nil {
+			if colTable.Invert() == table.Invert() && !colTable.IsStandalone() {
+				if err := s.forEachDataRange(colTable, begin, end, fn); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+		from, to := *header.snapshot, *header.snapshot
+		if table.Invert() {
+			from.setColumn(begin.column())
+			to.setColumn(end.column())
+		} else {
+			from.setRow(begin.row())
+			to.setRow(end.row())
+		}
+		if err := fn(column, from.String()+":"+to.String()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// conditionalFormats returns column cond style formats followed by cell and column style range formats
+func (c *Column) conditionalFormats(stylizer *Stylizer) ([]excelize.ConditionalFormatOptions, error) {
+	var ret []excelize.ConditionalFormatOptions
+	for _, condition := range c.Tag.Conditions {
+		format, err := stylizer.conditionalFormat(condition.Style)
+		if err != nil {
+			return nil, err
+		}
+		ret = append(ret, *format)
+	}
+	previous := ""
+	for _, styleTag := range []*StyleTag{c.Tag.CellStyle, c.Tag.ColumnStyle} {
+		if styleTag == nil || styleTag.Style == "" || styleTag.Style == previous {
+			continue
+		}
+		previous = styleTag.Style
+		if style := stylizer.Style(styleTag.Style); style != nil {
+			ret = append(ret, style.rangeFormats()...)
+		}
+	}
+	return ret, nil
+}
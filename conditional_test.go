@@ -0,0 +1,272 @@
+package xlsy
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/xuri/excelize/v2"
+	"testing"
+	"time"
+)
+
+func TestParseCondition(t *testing.T) {
+	var testCases = []struct {
+		description string
+		when        string
+		expect      *excelize.ConditionalFormatOptions
+		hasError    bool
+	}{
+		{
+			description: "less than",
+			when:        "<0",
+			expect:      &excelize.ConditionalFormatOptions{Type: "cell", Criteria: "<", Value: "0"},
+		},
+		{
+			description: "greater or equal",
+			when:        ">= 100.5",
+			expect:      &excelize.ConditionalFormatOptions{Type: "cell", Criteria: ">=", Value: "100.5"},
+		},
+		{
+			description: "equal",
+			when:        `=="x"`,
+			expect:      &excelize.ConditionalFormatOptions{Type: "cell", Criteria: "==", Value: `"x"`},
+		},
+		{
+			description: "between",
+			when:        "between 1 AND 10",
+			expect:      &excelize.ConditionalFormatOptions{Type: "cell", Criteria: "between", MinValue: "1", MaxValue: "10"},
+		},
+		{
+			description: "not between",
+			when:        "not between -1 and 1",
+			expect:      &excelize.ConditionalFormatOptions{Type: "cell", Criteria: "not between", MinValue: "-1", MaxValue: "1"},
+		},
+		{
+			description: "formula",
+			when:        "=$C2>TODAY()",
+			expect:      &excelize.ConditionalFormatOptions{Type: "formula", Criteria: "$C2>TODAY()"},
+		},
+		{
+			description: "missing value",
+			when:        "<",
+			hasError:    true,
+		},
+		{
+			description: "unsupported",
+			when:        "positive",
+			hasError:    true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		actual, err := parseCondition(testCase.when)
+		if testCase.hasError {
+			assert.NotNil(t, err, testCase.description)
+			continue
+		}
+		if !assert.Nil(t, err, testCase.description) {
+			continue
+		}
+		assert.EqualValues(t, testCase.expect, actual, testCase.description)
+	}
+}
+
+func TestMarshaller_Marshal_conditions(t *testing.T) {
+	type Invoice struct {
+		ID     int       `xls:"name=Id"`
+		Amount float64   `xls:"cond.style={when:'<0';color:red},cond.style={when:'between 1000 and 5000';background-color:yellow}"`
+		Due    time.Time `xls:"cond.style={when:\"=$C2<TODAY()\";font-style:bold}"`
+	}
+	type Line struct {
+		Seq  int
+		Cost float64 `xls:"cond.styleRef=negative"`
+	}
+	type Order struct {
+		ID    int
+		Lines []Line
+	}
+	now := time.Now()
+
+	var testCases = []struct {
+		description string
+		options     []Option
+		source      interface{}
+		expect      map[string][]excelize.ConditionalFormatOptions
+		hasError    bool
+	}{
+		{
+			description: "column data range",
+			source:      []Invoice{{ID: 1, Amount: -3, Due: now}, {ID: 2, Amount: 2000, Due: now}, {ID: 3, Amount: 1, Due: now}},
+			expect: map[string][]excelize.ConditionalFormatOptions{
+				"B2:B4": {
+					{Type: "cell", Criteria: "less than", Value: "0"},
+					{Type: "cell", Criteria: "between", MinValue: "1000", MaxValue: "5000"},
+				},
+				"C2:C4": {
+					{Type: "formula", Criteria: "$C2<TODAY()"},
+				},
+			},
+		},
+		{
+			description: "inverted table",
+			options:     []Option{WithInverted()},
+			source:      []Invoice{{ID: 1, Amount: -3, Due: now}, {ID: 2, Amount: 2000, Due: now}},
+			expect: map[string][]excelize.ConditionalFormatOptions{
+				"B2:C2": {
+					{Type: "cell", Criteria: "less than", Value: "0"},
+					{Type: "cell", Criteria: "between", MinValue: "1000", MaxValue: "5000"},
+				},
+				"B3:C3": {
+					{Type: "formula", Criteria: "$C2<TODAY()"},
+				},
+			},
+		},
+		{
+			description: "empty table",
+			source:      []Invoice{},
+			expect:      map[string][]excelize.ConditionalFormatOptions{},
+		},
+		{
+			description: "nested table with named style",
+			options:     []Option{WithNamedStyles("negative", "when:<0;color:red")},
+			source:      []Order{{ID: 1, Lines: []Line{{Seq: 1, Cost: 2}, {Seq: 2, Cost: -1}}}, {ID: 2, Lines: []Line{{Seq: 1, Cost: 3}}}},
+			expect: map[string][]excelize.ConditionalFormatOptions{
+				"C3:C5": {
+					{Type: "cell", Criteria: "less than", Value: "0"},
+				},
+			},
+		},
+		{
+			description: "invalid criteria",
+			source: []struct {
+				Amount float64 `xls:"cond.style={when:'positive';color:red}"`
+			}{{Amount: 1}},
+			hasError: true,
+		},
+		{
+			description: "streamed table",
+			options:     []Option{WithStreaming()},
+			source:      []Invoice{{ID: 1, Amount: -3, Due: now}},
+			hasError:    true,
+		},
+		{
+			description: "when in cell style",
+			source: []struct {
+				Amount float64 `xls:"style={when:'<0';color:red}"`
+			}{{Amount: 1}},
+			hasError: true,
+		},
+		{
+			description: "when in header style",
+			source: []struct {
+				Amount float64 `xls:"header.style={when:'<0';color:red}"`
+			}{{Amount: 1}},
+			hasError: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		file, err := marshalTestWorkbook(testCase.source, testCase.options...)
+		if testCase.hasError {
+			assert.NotNil(t, err, testCase.description)
+			continue
+		}
+		if !assert.Nil(t, err, testCase.description) {
+			continue
+		}
+		formats, err := file.GetConditionalFormats(file.GetSheetName(0))
+		assert.Nil(t, err, testCase.description)
+		for _, options := range formats {
+			for i := range options {
+				options[i].Format = 0
+			}
+		}
+		assert.EqualValues(t, testCase.expect, formats, testCase.description)
+		_ = file.Close()
+	}
+}
+
+func TestMarshaller_Marshal_rangeFormats(t *testing.T) {
+	type Metric struct {
+		Name  string
+		Score float64 `xls:"style={scale:red yellow green;format:0.00}"`
+		Usage int     `xls:"style={data-bar:#638EC6},cond.style={when:'>90';font-style:bold}"`
+		Trend int     `xls:"column.style={icon-set:3arrows}"`
+	}
+
+	var testCases = []struct {
+		description string
+		options     []Option
+		source      interface{}
+		expect      map[string][]excelize.ConditionalFormatOptions
+		hasError    bool
+	}{
+		{
+			description: "scale, data bar and icon set",
+			source:      []Metric{{Name: "a", Score: 0.5, Usage: 10, Trend: 1}, {Name: "b", Score: 0.9, Usage: 95, Trend: -1}},
+			expect: map[string][]excelize.ConditionalFormatOptions{
+				"B2:B3": {{Type: "3_color_scale", MinColor: "#FF0000", MidColor: "#FFFF00", MaxColor: "#008000"}},
+				"C2:C3": {{Type: "cell"}, {Type: "data_bar", BarColor: "#638EC6"}},
+				"D2:D3": {{Type: "icon_set", IconStyle: "3Arrows"}},
+			},
+		},
+		{
+			description: "invalid scale",
+			source: []struct {
+				Score float64 `xls:"style={scale:red}"`
+			}{{Score: 1}},
+			hasError: true,
+		},
+		{
+			description: "invalid icon set",
+			source: []struct {
+				Score float64 `xls:"style={icon-set:stars}"`
+			}{{Score: 1}},
+			hasError: true,
+		},
+		{
+			description: "streamed scale",
+			options:     []Option{WithStreaming()},
+			source: []struct {
+				Score float64 `xls:"style={scale:red yellow green}"`
+			}{{Score: 1}},
+			hasError: true,
+		},
+		{
+			description: "streamed data bar",
+			options:     []Option{WithStreaming()},
+			source: []struct {
+				Usage int `xls:"style={data-bar:#638EC6}"`
+			}{{Usage: 1}},
+			hasError: true,
+		},
+		{
+			description: "streamed icon set",
+			options:     []Option{WithStreaming()},
+			source: []struct {
+				Trend int `xls:"column.style={icon-set:3arrows}"`
+			}{{Trend: 1}},
+			hasError: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		file, err := marshalTestWorkbook(testCase.source, testCase.options...)
+		if testCase.hasError {
+			assert.NotNil(t, err, testCase.description)
+			continue
+		}
+		if !assert.Nil(t, err, testCase.description) {
+			continue
+		}
+		formats, err := file.GetConditionalFormats(file.GetSheetName(0))
+		assert.Nil(t, err, testCase.description)
+		actual := map[string][]excelize.ConditionalFormatOptions{}
+		for ref, options := range formats {
+			for _, option := range options {
+				actual[ref] = append(actual[ref], excelize.ConditionalFormatOptions{Type: option.Type, MinColor: option.MinColor,
+					MidColor: option.MidColor, MaxColor: option.MaxColor, BarColor: option.BarColor, IconStyle: option.IconStyle})
+			}
+		}
+		assert.EqualValues(t, testCase.expect, actual, testCase.description)
+		_ = file.Close()
+	}
+}
@@ -0,0 +1,111 @@
+package xlsy
+
+import (
+	"fmt"
+	"github.com/viant/xreflect"
+	"github.com/xuri/excelize/v2"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var timeLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02 15:04:05",
+	"2006/01/02 15:04:05",
+	"2006-01-02",
+	"2006/01/02",
+}
+
+// setCellValue converts raw cell text and sets it to dest value
+func setCellValue(dest reflect.Value, text string, tag *Tag, date1904 bool) error {
+	if text == "" {
+		return nil
+	}
+	if dest.Kind() == reflect.Ptr {
+		item := reflect.New(dest.Type().Elem())
+		if err := setCellValue(item.Elem(), text, tag, date1904); err != nil {
+			return err
+		}
+		dest.Set(item)
+		return nil
+	}
+	switch dest.Kind() {
+	case reflect.String:
+		dest.SetString(text)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(strings.TrimSpace(text))
+		if err != nil {
+			return fmt.Errorf("cannot convert %q to %s", text, dest.Type())
+		}
+		dest.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, err := parseInt(text, dest.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("cannot convert %q to %s", text, dest.Type())
+		}
+		dest.SetInt(i)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		i, err := parseInt(text, dest.Type().Bits())
+		if err != nil || i < 0 {
+			return fmt.Errorf("cannot convert %q to %s", text, dest.Type())
+		}
+		dest.SetUint(uint64(i))
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(strings.TrimSpace(text), dest.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("cannot convert %q to %s", text, dest.Type())
+		}
+		dest.SetFloat(f)
+	case reflect.Struct:
+		if dest.Type() != xreflect.TimeType {
+			return fmt.Errorf("unsupported type: %s", dest.Type())
+		}
+		ts, err := parseTime(text, tag, date1904)
+		if err != nil {
+			return fmt.Errorf("cannot convert %q to %s", text, dest.Type())
+		}
+		dest.Set(reflect.ValueOf(ts))
+	default:
+		return fmt.Errorf("unsupported type: %s", dest.Type())
+	}
+	return nil
+}
+
+func parseInt(text string, bits int) (int64, error) {
+	text = strings.TrimSpace(text)
+	i, err := strconv.ParseInt(text, 10, bits)
+	if err == nil {
+		return i, nil
+	}
+	f, fErr := strconv.ParseFloat(text, 64)
+	if fErr != nil || f != math.Trunc(f) {
+		return 0, err
+	}
+	return strconv.ParseInt(strconv.FormatFloat(f, 'f', -1, 64), 10, bits)
+}
+
+func parseTime(text string, tag *Tag, date1904 bool) (time.Time, error) {
+	text = strings.TrimSpace(text)
+	if serial, err := strconv.ParseFloat(text, 64); err == nil {
+		ts, err := excelize.ExcelDateToTime(serial, date1904)
+		if err != nil {
+			return ts, err
+		}
+		return ts.Round(time.Millisecond), nil
+	}
+	layouts := timeLayouts
+	if tag != nil && tag.TimeLayout != "" {
+		layouts = append([]string{tag.TimeLayout}, layouts...)
+	}
+	var err error
+	for _, layout := range layouts {
+		var ts time.Time
+		if ts, err = time.Parse(layout, text); err == nil {
+			return ts, nil
+		}
+	}
+	return time.Time{}, err
+}
@@ -0,0 +1,92 @@
+package xlsy
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+)
+
+// CSVEncoder represents delimiter separated values encoder, nested tables are flattened into dotted column names,
+// each worksheet is written with its own writer
+type CSVEncoder struct {
+	writer     func(sheet string) (io.Writer, error)
+	comma      rune
+	marshaller *Marshaller
+}
+
+// Encode encodes arbitrary type as delimiter separated values
+func (e *CSVEncoder) Encode(any interface{}) error {
+	return e.EncodeContext(context.Background(), any)
+}
+
+// EncodeContext encodes arbitrary type as delimiter separated values, cancellation is checked between rows
+func (e *CSVEncoder) EncodeContext(ctx context.Context, any interface{}) error {
+	sheets, err := e.marshaller.textSheets(ctx, any)
+	if err != nil {
+		return err
+	}
+	for _, aSheet := range sheets {
+		writer, err := e.writer(aSheet.name)
+		if err != nil {
+			return err
+		}
+		if err = e.encodeSheet(aSheet, writer); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (e *CSVEncoder) encodeSheet(aSheet *workSheet, writer io.Writer) error {
+	csvWriter := csv.NewWriter(writer)
+	csvWriter.Comma = e.comma
+	for i, table := range aSheet.tables {
+		if i > 0 { //tables sharing worksheet are separated with an empty line
+			if err := csvWriter.Write([]string{""}); err != nil {
+				return err
+			}
+		}
+		textTable := newTextTable(table)
+		if err := csvWriter.Write(textTable.names()); err != nil {
+			return err
+		}
+		err := textTable.lines(func(line []string) error {
+			if err := csvWriter.Write(line); err != nil {
+				return err
+			}
+			return aSheet.rowWritten()
+		})
+		if err != nil {
+			return err
+		}
+	}
+	csvWriter.Flush()
+	return csvWriter.Error()
+}
+
+// NewCSVEncoder creates comma separated values encoder writing a single worksheet
+func NewCSVEncoder(writer io.Writer, opts ...Option) *CSVEncoder {
+	return NewCSVSheetEncoder(singleSheetWriter(writer), ',', opts...)
+}
+
+// NewTSVEncoder creates tab separated values encoder writing a single worksheet
+func NewTSVEncoder(writer io.Writer, opts ...Option) *CSVEncoder {
+	return NewCSVSheetEncoder(singleSheetWriter(writer), '\t', opts...)
+}
+
+// NewCSVSheetEncoder creates delimiter separated values encoder writing each worksheet to the writer returned for the sheet name
+func NewCSVSheetEncoder(writer func(sheet string) (io.Writer, error), comma rune, opts ...Option) *CSVEncoder {
+	return &CSVEncoder{writer: writer, comma: comma, marshaller: NewMarshaller(opts...)}
+}
+
+func singleSheetWriter(writer io.Writer) func(sheet string) (io.Writer, error) {
+	var first string
+	return func(sheet string) (io.Writer, error) {
+		if first != "" {
+			return nil, fmt.Errorf("failed to encode worksheet %v: %v already written, use NewCSVSheetEncoder for multiple worksheets", sheet, first)
+		}
+		first = sheet
+		return writer, nil
+	}
+}
@@ -0,0 +1,117 @@
+package xlsy
+
+import (
+	"bytes"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"testing"
+	"time"
+)
+
+func TestCSVEncoder_Encode(t *testing.T) {
+	type Record struct {
+		ID      int       `xls:"name=Id,pos=2"`
+		Note    string    `xls:"-"`
+		Name    string    `xls:"pos=0"`
+		Gap     string    `xls:",blank"`
+		Amount  float64   `xls:"style={format:usd}"`
+		Ratio   float64   `xls:"style={format:pct}"`
+		Price   float64   `xls:"style={format:'###,##0.0000'}"`
+		Started time.Time `xls:"style={format:iso8601}"`
+	}
+	type Literal struct {
+		Balance  float64   `xls:"style={format:'#,##0.00;[Red]-#,##0.00'}"`
+		Price    float64   `xls:"style={format:'#,##0.00 \"USD\"'}"`
+		Duration int       `xls:"style={format:'0\\h'}"`
+		Started  time.Time `xls:"style={format:'yyyy \"year\"'}"`
+	}
+	type Item struct {
+		Seq     int
+		Product string
+	}
+	type Order struct {
+		ID    int
+		Items []*Item
+		Info  string
+	}
+	type Summary struct {
+		Report string
+		Total  float64 `xls:"style={format:eur}"`
+	}
+	type Holder struct {
+		Orders  []*Order
+		Summary Summary `xls:"worksheet=Totals"`
+	}
+	started := time.Date(2023, 8, 1, 10, 30, 0, 0, time.UTC)
+	orders := []*Order{
+		{ID: 1, Items: []*Item{{Seq: 1, Product: "P1"}, {Seq: 2, Product: "P2, large"}}, Info: "info 1"},
+		{ID: 2, Info: "info 2"},
+	}
+
+	var testCases = []struct {
+		description string
+		encoder     func(sheets map[string]*bytes.Buffer) *CSVEncoder
+		source      interface{}
+		expect      map[string]string
+	}{
+		{
+			description: "formatted columns",
+			encoder: func(sheets map[string]*bytes.Buffer) *CSVEncoder {
+				return NewCSVEncoder(sheets[defaultSheetName])
+			},
+			source: []Record{
+				{ID: 1, Note: "skip", Name: "name 1", Amount: 1234.5, Ratio: 0.25, Price: 1234.56789, Started: started},
+				{ID: 2, Name: "name 2", Amount: -3, Ratio: 1},
+			},
+			expect: map[string]string{defaultSheetName: `Name,Id,,Amount,Ratio,Price,Started
+name 1,1,,"$1,234.50",25%,"1,234.5679",2023/08/01 10:30:00
+name 2,2,,-$3.00,100%,0.0000,0001/01/01 00:00:00
+`},
+		},
+		{
+			description: "format sections and literals",
+			encoder: func(sheets map[string]*bytes.Buffer) *CSVEncoder {
+				return NewCSVEncoder(sheets[defaultSheetName])
+			},
+			source: []Literal{{Balance: -1234.5, Price: 1234, Duration: 12, Started: started}},
+			expect: map[string]string{defaultSheetName: `Balance,Price,Duration,Started
+"-1,234.50","1,234.00 USD",12h,2023 year
+`},
+		},
+		{
+			description: "nested relation",
+			encoder: func(sheets map[string]*bytes.Buffer) *CSVEncoder {
+				return NewTSVEncoder(sheets[defaultSheetName])
+			},
+			source: orders,
+			expect: map[string]string{defaultSheetName: "ID\tItems.Seq\tItems.Product\tInfo\n1\t1\tP1\tinfo 1\n1\t2\tP2, large\tinfo 1\n2\t\t\tinfo 2\n"},
+		},
+		{
+			description: "worksheet per file",
+			encoder: func(sheets map[string]*bytes.Buffer) *CSVEncoder {
+				return NewCSVSheetEncoder(func(sheet string) (io.Writer, error) {
+					return sheets[sheet], nil
+				}, ',')
+			},
+			source: &Holder{Orders: orders, Summary: Summary{Report: "Total", Total: 11.2}},
+			expect: map[string]string{
+				"Orders": "ID,Items.Seq,Items.Product,Info\n1,1,P1,info 1\n1,2,\"P2, large\",info 1\n2,,,info 2\n",
+				"Totals": "Report,Total\nTotal,€11.20\n",
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		sheets := map[string]*bytes.Buffer{}
+		for name := range testCase.expect {
+			sheets[name] = new(bytes.Buffer)
+		}
+		err := testCase.encoder(sheets).Encode(testCase.source)
+		if !assert.Nil(t, err, testCase.description) {
+			continue
+		}
+		for name, expect := range testCase.expect {
+			assert.EqualValues(t, expect, sheets[name].String(), testCase.description+" "+name)
+		}
+	}
+}
@@ -2,11 +2,26 @@ package xlsy
 
 import (
 	"fmt"
+	"github.com/xuri/excelize/v2"
 	"strconv"
 )
 
 type Cursor int
 
+func newCursor(row, column int) Cursor {
+	ret := Cursor(0)
+	ret.set(row, column)
+	return ret
+}
+
+func parseCursor(cell string) (Cursor, error) {
+	column, row, err := excelize.CellNameToCoordinates(cell)
+	if err != nil {
+		return 0, err
+	}
+	return newCursor(row-1, column-1), nil
+}
+
 func (c *Cursor) clone() Cursor {
 	return *c
 }
@@ -0,0 +1,27 @@
+package xlsy
+
+import (
+	"context"
+	"io"
+)
+
+// Encoder represents xls encoder writing workbook to io.Writer
+type Encoder struct {
+	writer     io.Writer
+	marshaller *Marshaller
+}
+
+// Encode encodes arbitrary type as xls workbook into the encoder writer
+func (e *Encoder) Encode(any interface{}) error {
+	return e.EncodeContext(context.Background(), any)
+}
+
+// EncodeContext encodes arbitrary type as xls workbook into the encoder writer, cancellation is checked between records and rows transfer
+func (e *Encoder) EncodeContext(ctx context.Context, any interface{}) error {
+	return e.marshaller.write(ctx, any, e.writer)
+}
+
+// NewEncoder creates an encoder with options
+func NewEncoder(writer io.Writer, opts ...Option) *Encoder {
+	return &Encoder{writer: writer, marshaller: NewMarshaller(opts...)}
+}
@@ -0,0 +1,59 @@
+package xlsy
+
+import (
+	"bytes"
+	"compress/gzip"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"testing"
+)
+
+func TestEncoder_Encode(t *testing.T) {
+	type Record struct {
+		ID     int
+		Name   string
+		Amount float64
+	}
+	records := []*Record{{ID: 1, Name: "name 1", Amount: 1.5}, {ID: 2, Name: "name 2", Amount: 3}}
+
+	var testCases = []struct {
+		description string
+		options     []Option
+		compressed  bool
+	}{
+		{description: "buffer"},
+		{description: "streaming buffer", options: []Option{WithStreaming()}},
+		{description: "gzip writer", compressed: true},
+	}
+
+	for _, testCase := range testCases {
+		buffer := new(bytes.Buffer)
+		var writer io.Writer = buffer
+		var gzipWriter *gzip.Writer
+		if testCase.compressed {
+			gzipWriter = gzip.NewWriter(buffer)
+			writer = gzipWriter
+		}
+		err := NewEncoder(writer, testCase.options...).Encode(records)
+		if !assert.Nil(t, err, testCase.description) {
+			continue
+		}
+		data := buffer.Bytes()
+		if gzipWriter != nil {
+			assert.Nil(t, gzipWriter.Close(), testCase.description)
+			reader, err := gzip.NewReader(buffer)
+			if !assert.Nil(t, err, testCase.description) {
+				continue
+			}
+			if data, err = io.ReadAll(reader); !assert.Nil(t, err, testCase.description) {
+				continue
+			}
+		}
+		var actual []*Record
+		err = NewUnmarshaller().Unmarshal(data, &actual)
+		if !assert.Nil(t, err, testCase.description) {
+			continue
+		}
+		assert.EqualValues(t, records, actual, testCase.description)
+	}
+}
@@ -0,0 +1,63 @@
+package xlsy
+
+import (
+	"fmt"
+	"strings"
+)
+
+type (
+	// MissingSheetError represents expected worksheets not found in a workbook
+	MissingSheetError struct {
+		Sheets []string
+	}
+
+	// CellError represents a cell decoding error
+	CellError struct {
+		Sheet  string
+		Cell   Cursor
+		Header string
+		Field  string
+		Value  string
+		Err    error
+	}
+
+	// CellErrors represents cell decoding errors collected in a workbook
+	CellErrors []*CellError
+)
+
+// Error returns error message
+func (e *MissingSheetError) Error() string {
+	return "missing worksheets: " + strings.Join(e.Sheets, ", ")
+}
+
+// Error returns error message
+func (e *CellError) Error() string {
+	location := fmt.Sprintf("Sheet %q %v", e.Sheet, e.Cell)
+	if e.Header != "" {
+		location += fmt.Sprintf(" (%s)", e.Header)
+	}
+	return fmt.Sprintf("%v: %v (field %s)", location, e.Err, e.Field)
+}
+
+// Unwrap returns underlying error
+func (e *CellError) Unwrap() error {
+	return e.Err
+}
+
+// Error returns error message
+func (e CellErrors) Error() string {
+	var messages = make([]string, 0, len(e))
+	for _, item := range e {
+		messages = append(messages, item.Error())
+	}
+	return strings.Join(messages, "\n")
+}
+
+// Unwrap returns collected errors
+func (e CellErrors) Unwrap() []error {
+	var ret = make([]error, 0, len(e))
+	for _, item := range e {
+		ret = append(ret, item)
+	}
+	return ret
+}
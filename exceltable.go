@@ -0,0 +1,86 @@
+package xlsy
+
+import (
+	"github.com/xuri/excelize/v2"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// defaultTableStyle represents excel table style used when excelTable=true
+const defaultTableStyle = "TableStyleMedium2"
+
+// excelTableStyle returns excel table built-in style name, or empty string if table is not rendered as excel table
+func (t *Table) excelTableStyle() string {
+	switch strings.ToLower(t.ExcelTable) {
+	case "", "false":
+		return ""
+	case "true":
+		return defaultTableStyle
+	}
+	return t.ExcelTable
+}
+
+// isExcelTable returns true if flat table is rendered as excel table
+func (t *Table) isExcelTable() bool {
+	return t.excelTableStyle() != "" && t.IsFlat()
+}
+
+// excelTable returns banded rows excel table over the table range, or nil
+func (s *workSheet) excelTable(table *Table, begin, end Cursor) *excelize.Table {
+	if !table.isExcelTable() {
+		return nil
+	}
+	name := table.TableName
+	if name == "" {
+		name = s.name
+	}
+	banded := true
+	return &excelize.Table{
+		Range:          begin.String() + ":" + end.String(),
+		Name:           s.uniqueTableName(name),
+		StyleName:      table.excelTableStyle(),
+		ShowRowStripes: &banded,
+	}
+}
+
+// addExcelTable renders table range as excel table
+func (s *workSheet) addExcelTable(table *Table, begin, end Cursor) error {
+	if excelTable := s.excelTable(table, begin, end); excelTable != nil {
+		return s.dest.AddTable(s.name, excelTable)
+	}
+	return nil
+}
+
+// uniqueTableName returns valid excel table name unique across the workbook
+func (s *workSheet) uniqueTableName(name string) string {
+	base := tableName(name)
+	ret := base
+	for i := 2; s.tableNames[strings.ToLower(ret)]; i++ {
+		ret = base + "_" + strconv.Itoa(i)
+	}
+	s.tableNames[strings.ToLower(ret)] = true
+	return ret
+}
+
+// tableName returns excel table name with unsupported characters replaced by underscore,
+// names starting with a digit or matching a cell reference are prefixed with underscore
+func tableName(name string) string {
+	runes := []rune(strings.TrimSpace(name))
+	for i, r := range runes {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			runes[i] = '_'
+		}
+	}
+	ret := string(runes)
+	if ret == "" {
+		return "Table"
+	}
+	if unicode.IsDigit(runes[0]) || strings.EqualFold(ret, "r") || strings.EqualFold(ret, "c") {
+		return "_" + ret
+	}
+	if _, _, err := excelize.CellNameToCoordinates(ret); err == nil {
+		return "_" + ret
+	}
+	return ret
+}
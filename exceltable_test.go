@@ -0,0 +1,99 @@
+package xlsy
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/xuri/excelize/v2"
+	"testing"
+)
+
+func TestTableName(t *testing.T) {
+	var testCases = []struct {
+		description string
+		name        string
+		expect      string
+	}{
+		{description: "valid", name: "Orders", expect: "Orders"},
+		{description: "spaces and punctuation", name: "Q1 Sales-2024", expect: "Q1_Sales_2024"},
+		{description: "leading digit", name: "2024", expect: "_2024"},
+		{description: "cell reference", name: "AB12", expect: "_AB12"},
+		{description: "row reference", name: "r", expect: "_r"},
+		{description: "empty", name: " ", expect: "Table"},
+	}
+
+	for _, testCase := range testCases {
+		assert.EqualValues(t, testCase.expect, tableName(testCase.name), testCase.description)
+	}
+}
+
+func TestMarshaller_Marshal_excelTable(t *testing.T) {
+	type Report struct {
+		Open   []testLine `xls:"excelTable=TableStyleLight9,tableName=Lines"`
+		Closed []testLine `xls:"excelTable=true,tableName=Lines"`
+	}
+	type Nested struct {
+		Orders []testOrder `xls:"excelTable=true"`
+	}
+
+	var testCases = []struct {
+		description string
+		options     []Option
+		source      interface{}
+		expect      map[string][]excelize.Table
+	}{
+		{
+			description: "flat table option",
+			options:     []Option{WithExcelTable("")},
+			source:      testLines,
+			expect:      map[string][]excelize.Table{"Sheet1": {{Range: "A1:B4", Name: "Sheet1", StyleName: "TableStyleMedium2"}}},
+		},
+		{
+			description: "unique names across worksheets",
+			source:      &Report{Open: testLines, Closed: testLines[:1]},
+			expect: map[string][]excelize.Table{
+				"Open":   {{Range: "A1:B4", Name: "Lines", StyleName: "TableStyleLight9"}},
+				"Closed": {{Range: "A1:B2", Name: "Lines_2", StyleName: "TableStyleMedium2"}},
+			},
+		},
+		{
+			description: "empty table",
+			options:     []Option{WithExcelTable("")},
+			source:      []testLine{},
+			expect:      map[string][]excelize.Table{"Sheet1": {{Range: "A1:B2", Name: "Sheet1", StyleName: "TableStyleMedium2"}}},
+		},
+		{
+			description: "streamed table",
+			options:     []Option{WithExcelTable("TableStyleDark1"), WithStreaming()},
+			source:      testLines,
+			expect:      map[string][]excelize.Table{"Sheet1": {{Range: "A1:B4", Name: "Sheet1", StyleName: "TableStyleDark1"}}},
+		},
+		{
+			description: "nested table is not rendered as excel table",
+			source:      &Nested{Orders: testOrders},
+			expect:      map[string][]excelize.Table{"Orders": nil},
+		},
+		{
+			description: "inverted table is not rendered as excel table",
+			options:     []Option{WithExcelTable(""), WithInverted()},
+			source:      testLines,
+			expect:      map[string][]excelize.Table{"Sheet1": nil},
+		},
+	}
+
+	for _, testCase := range testCases {
+		file, err := marshalTestWorkbook(testCase.source, testCase.options...)
+		if !assert.Nil(t, err, testCase.description) {
+			continue
+		}
+		for sheet, expect := range testCase.expect {
+			tables, err := file.GetTables(sheet)
+			assert.Nil(t, err, testCase.description)
+			var actual []excelize.Table
+			for _, table := range tables {
+				actual = append(actual, excelize.Table{Range: table.Range, Name: table.Name, StyleName: table.StyleName})
+				assert.True(t, *table.ShowRowStripes, testCase.description)
+			}
+			assert.EqualValues(t, expect, actual, testCase.description+" "+sheet)
+		}
+		_ = file.Close()
+	}
+}
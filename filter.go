@@ -0,0 +1,22 @@
+package xlsy
+
+// tableRange returns table range from the deepest header row through the last data row
+func (t *Table) tableRange(data, end Cursor) (Cursor, Cursor, bool) {
+	from, to, ok := t.headerSpan()
+	if !ok || data.row() == 0 {
+		return 0, 0, false
+	}
+	return newCursor(data.row()-1, from), newCursor(end.row(), to), true
+}
+
+// autoFilter applies auto filter on the table range, inverted tables are not filtered, worksheet holds a single auto filter;
+// excel tables come with their own filter
+func (s *workSheet) autoFilter(table *Table, begin, end Cursor) error {
+	if !table.AutoFilter || table.Invert() || table.isExcelTable() {
+		return nil
+	}
+	if end.row() < begin.row() {
+		end.setRow(begin.row())
+	}
+	return s.dest.AutoFilter(s.name, begin.String()+":"+end.String(), nil)
+}
@@ -0,0 +1,77 @@
+package xlsy
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestMarshaller_Marshal_autoFilter(t *testing.T) {
+	type Report struct {
+		Orders []testOrder `xls:"autoFilter=true"`
+	}
+
+	var testCases = []struct {
+		description string
+		options     []Option
+		source      interface{}
+		expect      string
+		hasError    bool
+	}{
+		{
+			description: "no filter",
+			source:      testLines,
+		},
+		{
+			description: "flat table option",
+			options:     []Option{WithAutoFilter()},
+			source:      testLines,
+			expect:      "'Sheet1'!$A$1:$B$4",
+		},
+		{
+			description: "nested table deepest header row",
+			source:      &Report{Orders: testOrders},
+			expect:      "'Orders'!$A$2:$C$5",
+		},
+		{
+			description: "inverted table",
+			options:     []Option{WithAutoFilter(), WithInverted()},
+			source:      testLines,
+		},
+		{
+			description: "empty table",
+			options:     []Option{WithAutoFilter()},
+			source:      []testLine{},
+			expect:      "'Sheet1'!$A$1:$B$1",
+		},
+		{
+			description: "streamed table",
+			options:     []Option{WithAutoFilter(), WithStreaming()},
+			source:      testLines,
+			hasError:    true,
+		},
+		{
+			description: "streamed excel table",
+			options:     []Option{WithAutoFilter(), WithExcelTable("TableStyleMedium2"), WithStreaming()},
+			source:      testLines,
+		},
+	}
+
+	for _, testCase := range testCases {
+		file, err := marshalTestWorkbook(testCase.source, testCase.options...)
+		if testCase.hasError {
+			assert.NotNil(t, err, testCase.description)
+			continue
+		}
+		if !assert.Nil(t, err, testCase.description) {
+			continue
+		}
+		actual := ""
+		for _, definedName := range file.GetDefinedName() {
+			if definedName.Name == "_xlnm._FilterDatabase" {
+				actual = definedName.RefersTo
+			}
+		}
+		assert.EqualValues(t, testCase.expect, actual, testCase.description)
+		_ = file.Close()
+	}
+}
@@ -0,0 +1,65 @@
+package xlsy
+
+import (
+	"fmt"
+	"github.com/xuri/excelize/v2"
+	"strings"
+)
+
+const (
+	freezeHeader = "header"
+	freezeNone   = "none"
+)
+
+func (t *Tag) updateFreeze(value string) error {
+	value = strings.ToLower(strings.TrimSpace(value))
+	switch value {
+	case freezeHeader, freezeNone:
+		t.Freeze = value
+		return nil
+	}
+	return fmt.Errorf("unsupported freeze: %v, expected: %v or %v", value, freezeHeader, freezeNone)
+}
+
+// freezePanes returns panes frozen at the first data cell after table header rows and leading key columns, or nil
+func (t *Table) freezePanes(addr, data Cursor) *excelize.Panes {
+	if t.Freeze != freezeHeader {
+		return nil
+	}
+	xSplit, ySplit := data.column(), 0
+	if t.FreezeColumns > 0 {
+		ySplit = addr.row() + t.FreezeColumns
+	}
+	if !t.Invert() {
+		xSplit, ySplit = 0, data.row()
+		if t.FreezeColumns > 0 {
+			xSplit = addr.column() + t.FreezeColumns
+		}
+	}
+	if xSplit == 0 && ySplit == 0 {
+		return nil
+	}
+	ret := &excelize.Panes{Freeze: true, XSplit: xSplit, YSplit: ySplit, TopLeftCell: newCursor(ySplit, xSplit).String()}
+	switch {
+	case xSplit > 0 && ySplit > 0:
+		ret.ActivePane = "bottomRight"
+	case ySplit > 0:
+		ret.ActivePane = "bottomLeft"
+	default:
+		ret.ActivePane = "topRight"
+	}
+	ret.Selection = []excelize.Selection{{SQRef: ret.TopLeftCell, ActiveCell: ret.TopLeftCell, Pane: ret.ActivePane}}
+	return ret
+}
+
+// freeze freezes worksheet panes at the table data cell
+func (s *workSheet) freeze(table *Table, addr, data Cursor) error {
+	panes := table.freezePanes(addr, data)
+	if panes == nil {
+		return nil
+	}
+	if err := s.ensureWorksheet(); err != nil {
+		return err
+	}
+	return s.dest.SetPanes(s.name, panes)
+}
@@ -0,0 +1,89 @@
+package xlsy
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestMarshaller_Marshal_freeze(t *testing.T) {
+	type Report struct {
+		Orders []testOrder `xls:"freeze=header,freezeColumns=1"`
+	}
+	type Invalid struct {
+		Orders []testOrder `xls:"freeze=footer"`
+	}
+
+	type panes struct {
+		xSplit, ySplit int
+		topLeftCell    string
+		activePane     string
+	}
+	var testCases = []struct {
+		description string
+		options     []Option
+		source      interface{}
+		expect      panes
+		hasError    bool
+	}{
+		{
+			description: "no freeze",
+			source:      testOrders,
+		},
+		{
+			description: "header option",
+			options:     []Option{WithFreeze(0)},
+			source:      testLines,
+			expect:      panes{ySplit: 1, topLeftCell: "A2", activePane: "bottomLeft"},
+		},
+		{
+			description: "empty table header",
+			options:     []Option{WithFreeze(0)},
+			source:      []testLine{},
+			expect:      panes{ySplit: 1, topLeftCell: "A2", activePane: "bottomLeft"},
+		},
+		{
+			description: "nested header with key column",
+			source:      &Report{Orders: testOrders},
+			expect:      panes{xSplit: 1, ySplit: 2, topLeftCell: "B3", activePane: "bottomRight"},
+		},
+		{
+			description: "inverted header",
+			options:     []Option{WithFreeze(0), WithInverted()},
+			source:      testLines,
+			expect:      panes{xSplit: 1, topLeftCell: "B1", activePane: "topRight"},
+		},
+		{
+			description: "inverted header with key row",
+			options:     []Option{WithFreeze(1), WithInverted()},
+			source:      testLines,
+			expect:      panes{xSplit: 1, ySplit: 1, topLeftCell: "B2", activePane: "bottomRight"},
+		},
+		{
+			description: "streamed header",
+			options:     []Option{WithFreeze(1), WithStreaming()},
+			source:      testLines,
+			expect:      panes{xSplit: 1, ySplit: 1, topLeftCell: "B2", activePane: "bottomRight"},
+		},
+		{
+			description: "invalid freeze",
+			source:      &Invalid{Orders: testOrders},
+			hasError:    true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		file, err := marshalTestWorkbook(testCase.source, testCase.options...)
+		if testCase.hasError {
+			assert.NotNil(t, err, testCase.description)
+			continue
+		}
+		if !assert.Nil(t, err, testCase.description) {
+			continue
+		}
+		actual, err := file.GetPanes(file.GetSheetName(0))
+		assert.Nil(t, err, testCase.description)
+		assert.EqualValues(t, testCase.expect.xSplit != 0 || testCase.expect.ySplit != 0, actual.Freeze, testCase.description)
+		assert.EqualValues(t, testCase.expect, panes{xSplit: actual.XSplit, ySplit: actual.YSplit, topLeftCell: actual.TopLeftCell, activePane: actual.ActivePane}, testCase.description)
+		_ = file.Close()
+	}
+}
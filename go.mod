@@ -11,6 +11,7 @@ require (
 	github.com/viant/xreflect v0.3.1
 	github.com/viant/xunsafe v0.9.0
 	github.com/xuri/excelize/v2 v2.8.0
+	golang.org/x/text v0.12.0
 )
 
 require (
@@ -27,6 +28,5 @@ require (
 	golang.org/x/crypto v0.12.0 // indirect
 	golang.org/x/net v0.14.0 // indirect
 	golang.org/x/sys v0.11.0 // indirect
-	golang.org/x/text v0.12.0 // indirect
 	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
@@ -0,0 +1,109 @@
+package xlsy
+
+import (
+	"github.com/xuri/excelize/v2"
+	"strings"
+)
+
+// grid represents a worksheet cells snapshot used by unmarshaller
+type grid struct {
+	sheet    string
+	cells    [][]string
+	merged   map[Cursor]Cursor
+	date1904 bool
+	inverted bool
+}
+
+func newGrid(src *excelize.File, sheet string) (*grid, error) {
+	cells, err := src.GetRows(sheet, excelize.Options{RawCellValue: true})
+	if err != nil {
+		return nil, err
+	}
+	ret := &grid{sheet: sheet, cells: cells, merged: map[Cursor]Cursor{}}
+	mergeCells, err := src.GetMergeCells(sheet)
+	if err != nil {
+		return nil, err
+	}
+	for _, cell := range mergeCells {
+		begin, err := parseCursor(cell.GetStartAxis())
+		if err != nil {
+			return nil, err
+		}
+		end, err := parseCursor(cell.GetEndAxis())
+		if err != nil {
+			return nil, err
+		}
+		ret.merged[begin] = end
+	}
+	props, err := src.GetWorkbookProps()
+	if err != nil {
+		return nil, err
+	}
+	if props.Date1904 != nil {
+		ret.date1904 = *props.Date1904
+	}
+	return ret, nil
+}
+
+func (g *grid) height() int {
+	return len(g.cells)
+}
+
+func (g *grid) width(row int) int {
+	if row < 0 || row >= len(g.cells) {
+		return 0
+	}
+	return len(g.cells[row])
+}
+
+func (g *grid) value(cur Cursor) string {
+	row, column := cur.row(), cur.column()
+	if row < 0 || row >= len(g.cells) {
+		return ""
+	}
+	if column < 0 || column >= len(g.cells[row]) {
+		return ""
+	}
+	return g.cells[row][column]
+}
+
+// address returns worksheet cell address for grid cursor
+func (g *grid) address(cur Cursor) Cursor {
+	if g.inverted {
+		return newCursor(cur.column(), cur.row())
+	}
+	return cur
+}
+
+// find returns a column of the first cell matching text in [begin, end) row range or -1
+func (g *grid) find(row, begin, end int, text string) int {
+	if end < 0 || end > g.width(row) {
+		end = g.width(row)
+	}
+	for column := begin; column < end; column++ {
+		if strings.EqualFold(strings.TrimSpace(g.cells[row][column]), text) {
+			return column
+		}
+	}
+	return -1
+}
+
+// transpose returns grid with rows and columns swapped, used for inverted tables
+func (g *grid) transpose() *grid {
+	ret := &grid{sheet: g.sheet, merged: map[Cursor]Cursor{}, date1904: g.date1904, inverted: !g.inverted}
+	for row, cells := range g.cells {
+		for column, cell := range cells {
+			for len(ret.cells) <= column {
+				ret.cells = append(ret.cells, nil)
+			}
+			for len(ret.cells[column]) <= row {
+				ret.cells[column] = append(ret.cells[column], "")
+			}
+			ret.cells[column][row] = cell
+		}
+	}
+	for begin, end := range g.merged {
+		ret.merged[newCursor(begin.column(), begin.row())] = newCursor(end.column(), end.row())
+	}
+	return ret
+}
@@ -0,0 +1,460 @@
+package xlsy
+
+import (
+	"context"
+	"fmt"
+	"github.com/xuri/excelize/v2"
+	"html"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// HTMLEncoder represents html encoder rendering worksheets as <table> markup with inline css derived from xls styles
+type HTMLEncoder struct {
+	writer     io.Writer
+	marshaller *Marshaller
+}
+
+// htmlTable represents worksheet cells laid out as html table, rows are written to the writer once complete
+type htmlTable struct {
+	writer   io.Writer
+	sheet    *workSheet
+	stylizer *Stylizer
+	caption  bool
+	begun    bool
+	values   map[Cursor]interface{}
+	styles   map[Cursor]int
+	merged   map[Cursor]Cursor
+	covered  map[Cursor]bool
+	widths   map[int]float64
+	headers  map[Cursor]bool
+	css      map[int]string
+	width    int //laid out columns
+	height   int //laid out rows
+	written  int //rows written
+}
+
+// Encode encodes arbitrary type as html tables
+func (e *HTMLEncoder) Encode(any interface{}) error {
+	return e.EncodeContext(context.Background(), any)
+}
+
+// EncodeContext encodes arbitrary type as html tables, cancellation is checked between rows layout
+func (e *HTMLEncoder) EncodeContext(ctx context.Context, any interface{}) error {
+	dest := excelize.NewFile() //style registry
+	defer dest.Close()
+	aSession, _, source, err := e.marshaller.build(ctx, any, dest)
+	if err != nil {
+		return err
+	}
+	for _, name := range aSession.names {
+		aSheet := aSession.sheets[name]
+		if err = e.marshaller.setSourceData(ctx, aSheet); err != nil {
+			return err
+		}
+		if source != nil && source.err != nil {
+			return source.err
+		}
+		table := newHTMLTable(e.writer, aSheet, aSession.stylizer, len(aSession.names) > 1)
+		if err = table.layout(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func newHTMLTable(writer io.Writer, aSheet *workSheet, stylizer *Stylizer, caption bool) *htmlTable {
+	ret := &htmlTable{writer: writer, sheet: aSheet, stylizer: stylizer, caption: caption,
+		values: map[Cursor]interface{}{}, styles: map[Cursor]int{}, merged: map[Cursor]Cursor{}, covered: map[Cursor]bool{},
+		widths: map[int]float64{}, headers: map[Cursor]bool{}, css: map[int]string{}}
+	aSheet.cells = ret
+	return ret
+}
+
+// layout lays out sheet tables cells, worksheet features (freeze, conditional formats, validations, auto width) are not rendered
+func (t *htmlTable) layout() error {
+	loc := Cursor(0)
+	for _, table := range t.sheet.tables {
+		if _, err := t.sheet.layoutTable(table, &loc); err != nil {
+			return err
+		}
+		if err := t.sheet.drawTableBorder(table, loc, t.sheet.extent); err != nil {
+			return err
+		}
+	}
+	if err := t.writeRows(t.height); err != nil {
+		return err
+	}
+	_, err := io.WriteString(t.writer, "</table>\n")
+	return err
+}
+
+// flushRows writes rows laid out before a top level table record, inverted or bordered tables cells are changed until the table end
+func (t *htmlTable) flushRows(table *Table, rows int) error {
+	if table.Invert() || table.Tag.Box != "" || table.Tag.Grid != "" {
+		return nil
+	}
+	return t.writeRows(rows)
+}
+
+func (t *htmlTable) SetCellValue(sheet, cell string, value interface{}) error {
+	cur, err := t.cursor(cell)
+	if err != nil {
+		return err
+	}
+	t.values[cur] = value
+	return nil
+}
+
+func (t *htmlTable) SetCellStyle(sheet, hCell, vCell string, styleID int) error {
+	return t.forEachCell(hCell, vCell, func(cur Cursor) {
+		t.styles[cur] = styleID
+	})
+}
+
+func (t *htmlTable) GetCellStyle(sheet, cell string) (int, error) {
+	cur, err := parseCursor(cell)
+	if err != nil {
+		return 0, err
+	}
+	return t.styles[cur], nil
+}
+
+func (t *htmlTable) MergeCell(sheet, hCell, vCell string) error {
+	begin, err := t.cursor(hCell)
+	if err != nil {
+		return err
+	}
+	end, err := t.cursor(vCell)
+	if err != nil || begin == end {
+		return err
+	}
+	t.merged[begin] = end
+	return t.forEachCell(hCell, vCell, func(cur Cursor) {
+		if cur != begin {
+			t.covered[cur] = true
+		}
+	})
+}
+
+func (t *htmlTable) SetColWidth(sheet, startCol, endCol string, width float64) error {
+	from, err := excelize.ColumnNameToNumber(startCol)
+	if err != nil {
+		return err
+	}
+	to, err := excelize.ColumnNameToNumber(endCol)
+	if err != nil {
+		return err
+	}
+	for column := from - 1; column < to; column++ {
+		t.widths[column] = width
+	}
+	return nil
+}
+
+// cursor parses cell address and extends laid out table dimension
+func (t *htmlTable) cursor(cell string) (Cursor, error) {
+	cur, err := parseCursor(cell)
+	if err != nil {
+		return 0, err
+	}
+	if cur.row() >= t.height {
+		t.height = cur.row() + 1
+	}
+	if cur.column() >= t.width {
+		t.width = cur.column() + 1
+	}
+	return cur, nil
+}
+
+func (t *htmlTable) forEachCell(hCell, vCell string, fn func(cur Cursor)) error {
+	begin, err := t.cursor(hCell)
+	if err != nil {
+		return err
+	}
+	end, err := t.cursor(vCell)
+	if err != nil {
+		return err
+	}
+	for row := begin.row(); row <= end.row(); row++ {
+		for column := begin.column(); column <= end.column(); column++ {
+			fn(newCursor(row, column))
+		}
+	}
+	return nil
+}
+
+// begin writes table opening, caption and custom column widths
+func (t *htmlTable) begin() error {
+	if t.begun {
+		return nil
+	}
+	t.begun = true
+	builder := []string{"<table>\n"}
+	if t.caption {
+		builder = append(builder, "<caption>"+html.EscapeString(t.sheet.name)+"</caption>\n")
+	}
+	if len(t.widths) > 0 {
+		cols := make([]string, t.width)
+		for column := range cols {
+			cols[column] = "<col>"
+			if width, ok := t.widths[column]; ok {
+				cols[column] = `<col style="width:` + strconv.FormatFloat(width*pixelRatio, 'f', -1, 64) + `px">`
+			}
+		}
+		builder = append(builder, "<colgroup>"+strings.Join(cols, "")+"</colgroup>\n")
+	}
+	_, err := io.WriteString(t.writer, strings.Join(builder, ""))
+	return err
+}
+
+// writeRows writes laid out rows up to rows count
+func (t *htmlTable) writeRows(rows int) error {
+	if err := t.begin(); err != nil {
+		return err
+	}
+	if t.written >= rows {
+		return nil
+	}
+	for _, table := range t.sheet.tables {
+		t.addHeaders(table)
+	}
+	for ; t.written < rows; t.written++ {
+		line := []string{"<tr>"}
+		for column := 0; column < t.width; column++ {
+			cur := newCursor(t.written, column)
+			if t.covered[cur] {
+				continue
+			}
+			cell, err := t.cell(cur)
+			if err != nil {
+				return err
+			}
+			line = append(line, cell)
+		}
+		line = append(line, "</tr>\n")
+		if _, err := io.WriteString(t.writer, strings.Join(line, "")); err != nil {
+			return err
+		}
+		for column := 0; column < t.width; column++ {
+			cur := newCursor(t.written, column)
+			delete(t.values, cur)
+			delete(t.styles, cur)
+		}
+	}
+	return nil
+}
+
+// addHeaders registers table header cells rendered as <th>
+func (t *htmlTable) addHeaders(table *Table) {
+	if table.Header == nil {
+		return
+	}
+	for i, header := range table.Header.Values {
+		if header.snapshot != nil {
+			t.headers[*header.snapshot] = true
+		}
+		if column := table.columnByIndex(i); column.Table != nil {
+			t.addHeaders(column.Table)
+		}
+	}
+}
+
+func (t *htmlTable) cell(cur Cursor) (string, error) {
+	tag := "td"
+	if t.headers[cur] {
+		tag = "th"
+	}
+	ret := "<" + tag
+	if end, ok := t.merged[cur]; ok {
+		if columns := end.column() - cur.column() + 1; columns > 1 {
+			ret += ` colspan="` + strconv.Itoa(columns) + `"`
+		}
+		if rows := end.row() - cur.row() + 1; rows > 1 {
+			ret += ` rowspan="` + strconv.Itoa(rows) + `"`
+		}
+	}
+	style, err := t.cellStyle(cur)
+	if err != nil {
+		return "", err
+	}
+	if css := t.cellCSS(style); css != "" {
+		ret += ` style="` + html.EscapeString(css) + `"`
+	}
+	return ret + ">" + html.EscapeString(valueText(t.values[cur], style)) + "</" + tag + ">", nil
+}
+
+// cellStyle returns registered cell style, styles created outside the stylizer are read from the style registry file
+func (t *htmlTable) cellStyle(cur Cursor) (*extStyle, error) {
+	styleID := t.styles[cur]
+	if styleID == 0 {
+		return nil, nil
+	}
+	if style := t.stylizer.extStyle(styleID); style != nil {
+		return style, nil
+	}
+	fileStyle, err := t.stylizer.file.GetStyle(styleID)
+	if err != nil {
+		return nil, err
+	}
+	style := &extStyle{ID: &styleID, Style: fileStyle}
+	t.stylizer.derive(styleID, style)
+	return style, nil
+}
+
+func (t *htmlTable) cellCSS(style *extStyle) string {
+	if style == nil || style.ID == nil {
+		return ""
+	}
+	if css, ok := t.css[*style.ID]; ok {
+		return css
+	}
+	css := style.css()
+	t.css[*style.ID] = css
+	return css
+}
+
+// valueText returns cell value text formatted with the cell style number format, unstyled times use excel default date time format
+func valueText(value interface{}, style *extStyle) string {
+	numFmt := style.numFormat()
+	rValue := reflect.ValueOf(value)
+	for rValue.Kind() == reflect.Ptr {
+		if rValue.IsNil() {
+			return ""
+		}
+		rValue = rValue.Elem()
+	}
+	if !rValue.IsValid() {
+		return ""
+	}
+	var number float64
+	switch actual := rValue.Interface().(type) {
+	case time.Time:
+		if !isDateFormat(numFmt) {
+			numFmt = builtInNumFormats[22]
+		}
+		return actual.Format(timeLayout(numFmt))
+	case []byte:
+		return string(actual)
+	}
+	switch rValue.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if numFmt == "" {
+			return strconv.FormatInt(rValue.Int(), 10)
+		}
+		number = float64(rValue.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if numFmt == "" {
+			return strconv.FormatUint(rValue.Uint(), 10)
+		}
+		number = float64(rValue.Uint())
+	case reflect.Float32, reflect.Float64:
+		if numFmt == "" {
+			return strconv.FormatFloat(rValue.Float(), 'f', -1, rValue.Type().Bits())
+		}
+		number = rValue.Float()
+	case reflect.Bool:
+		return strings.ToUpper(strconv.FormatBool(rValue.Bool()))
+	default:
+		return fmt.Sprint(rValue.Interface())
+	}
+	if isDateFormat(numFmt) {
+		if ts, err := excelize.ExcelDateToTime(number, false); err == nil {
+			return ts.Format(timeLayout(numFmt))
+		}
+	}
+	return formatNumber(number, numFmt)
+}
+
+// css returns inline css for the style
+func (e *extStyle) css() string {
+	if e.Style == nil {
+		return ""
+	}
+	var items []string
+	if font := e.Font; font != nil {
+		if font.Bold {
+			items = append(items, "font-weight:bold")
+		}
+		if font.Italic {
+			items = append(items, "font-style:italic")
+		}
+		var decorations []string
+		switch font.Underline {
+		case "single":
+			decorations = append(decorations, "underline")
+		case "double":
+			decorations = append(decorations, "underline double")
+		}
+		if font.Strike {
+			decorations = append(decorations, "line-through")
+		}
+		if len(decorations) > 0 {
+			items = append(items, "text-decoration:"+strings.Join(decorations, " "))
+		}
+		if font.Size > 0 && font.Size != defaultFontSize {
+			items = append(items, "font-size:"+strconv.FormatFloat(font.Size, 'f', -1, 64)+"pt")
+		}
+		switch font.VertAlign {
+		case "superscript":
+			items = append(items, "vertical-align:super")
+		case "subscript":
+			items = append(items, "vertical-align:sub")
+		}
+		if font.Family != "" {
+			items = append(items, "font-family:"+font.Family)
+		}
+		if font.Color != "" {
+			items = append(items, "color:"+font.Color)
+		}
+	}
+	if fill := e.Fill; len(fill.Color) > 0 {
+		switch fill.Type {
+		case "gradient":
+			items = append(items, "background:linear-gradient("+strings.Join(fill.Color, ",")+")")
+		default:
+			items = append(items, "background-color:"+fill.Color[0])
+		}
+	}
+	if alignment := e.Alignment; alignment != nil {
+		if alignment.Horizontal != "" {
+			items = append(items, "text-align:"+alignment.Horizontal)
+		}
+		switch alignment.Vertical {
+		case "":
+		case "center":
+			items = append(items, "vertical-align:middle")
+		default:
+			items = append(items, "vertical-align:"+alignment.Vertical)
+		}
+		if alignment.WrapText {
+			items = append(items, "white-space:normal")
+		}
+		if alignment.Indent > 0 {
+			items = append(items, fmt.Sprintf("padding-left:%vem", alignment.Indent))
+		}
+		switch rotation := alignment.TextRotation; {
+		case rotation == 255:
+			items = append(items, "writing-mode:vertical-lr;text-orientation:upright")
+		case rotation > 90:
+			items = append(items, fmt.Sprintf("transform:rotate(%vdeg)", rotation-90))
+		case rotation > 0:
+			items = append(items, fmt.Sprintf("transform:rotate(-%vdeg)", rotation))
+		}
+	}
+	for _, border := range e.Border {
+		items = append(items, "border-"+border.Type+":"+borderCSS(border))
+	}
+	if e.Height != nil {
+		items = append(items, "height:"+strconv.FormatFloat(e.Height.Size, 'f', -1, 64)+e.Height.Unit)
+	}
+	return strings.Join(items, ";")
+}
+
+// NewHTMLEncoder creates html encoder with options
+func NewHTMLEncoder(writer io.Writer, opts ...Option) *HTMLEncoder {
+	return &HTMLEncoder{writer: writer, marshaller: NewMarshaller(opts...)}
+}
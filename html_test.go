@@ -0,0 +1,134 @@
+package xlsy
+
+import (
+	"bytes"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestHTMLEncoder_Encode(t *testing.T) {
+	type Item struct {
+		Seq     int
+		Product string
+	}
+	type Order struct {
+		ID     int     `xls:"name=Id,style={color:red;text-align:center;width:60px}"`
+		Amount float64 `xls:"style={format:usd;background-color:yellow}"`
+		Items  []*Item
+		Info   string
+	}
+	type Summary struct {
+		Report string
+		Total  float64
+	}
+	type Note struct {
+		Title string `xls:"style={font-size:14pt;text-decoration:underline;border-bottom:2px solid #000}"`
+		Mark  string `xls:"style={vertical-align:super;text-rotation:-45deg}"`
+	}
+	type Holder struct {
+		Orders  []*Order
+		Summary Summary `xls:"worksheet=Totals"`
+	}
+	orders := []*Order{
+		{ID: 1, Amount: 1234.5, Items: []*Item{{Seq: 1, Product: "P1"}, {Seq: 2, Product: "P<2>"}}, Info: "info 1"},
+		{ID: 2, Amount: 3, Info: "info 2"},
+	}
+
+	var testCases = []struct {
+		description string
+		options     []Option
+		source      interface{}
+		expect      string
+	}{
+		{
+			description: "merged multi level header",
+			source:      orders,
+			expect: `<table>
+<colgroup><col style="width:60px"><col><col><col><col></colgroup>
+<tr><th rowspan="2" style="font-weight:bold">Id</th><th rowspan="2" style="font-weight:bold">Amount</th><th colspan="2">Items</th><th rowspan="2" style="font-weight:bold">Info</th></tr>
+<tr><th style="font-weight:bold">Seq</th><th style="font-weight:bold">Product</th></tr>
+<tr><td style="color:#ff0000;text-align:center">1</td><td style="background-color:#ffff00">$1,234.50</td><td>1</td><td>P1</td><td>info 1</td></tr>
+<tr><td></td><td></td><td>2</td><td>P&lt;2&gt;</td><td></td></tr>
+<tr><td style="color:#ff0000;text-align:center">2</td><td style="background-color:#ffff00">$3.00</td><td></td><td></td><td>info 2</td></tr>
+</table>
+`,
+		},
+		{
+			description: "inverted",
+			options:     []Option{WithInverted()},
+			source:      orders,
+			expect: `<table>
+<colgroup><col><col><col style="width:60px"><col><col></colgroup>
+<tr><th colspan="2" style="font-weight:bold">Id</th><td style="color:#ff0000;text-align:center">1</td><td></td><td style="color:#ff0000;text-align:center">2</td></tr>
+<tr><th colspan="2" style="font-weight:bold">Amount</th><td style="background-color:#ffff00">$1,234.50</td><td></td><td style="background-color:#ffff00">$3.00</td></tr>
+<tr><th rowspan="2">Items</th><th style="font-weight:bold">Seq</th><td>1</td><td>2</td><td></td></tr>
+<tr><th style="font-weight:bold">Product</th><td>P1</td><td>P&lt;2&gt;</td><td></td></tr>
+<tr><th colspan="2" style="font-weight:bold">Info</th><td>info 1</td><td></td><td>info 2</td></tr>
+</table>
+`,
+		},
+		{
+			description: "worksheet captions, empty table header",
+			source:      &Holder{Summary: Summary{Report: "Total", Total: 11.2}},
+			expect: `<table>
+<caption>Orders</caption>
+<tr><th rowspan="2" style="font-weight:bold">Id</th><th rowspan="2" style="font-weight:bold">Amount</th><th colspan="2">Items</th><th rowspan="2" style="font-weight:bold">Info</th></tr>
+<tr><th style="font-weight:bold">Seq</th><th style="font-weight:bold">Product</th></tr>
+</table>
+<table>
+<caption>Totals</caption>
+<tr><th style="font-weight:bold">Report</th><th style="font-weight:bold">Total</th></tr>
+<tr><td>Total</td><td>11.2</td></tr>
+</table>
+`,
+		},
+		{
+			description: "text decoration, rotation and borders",
+			source:      []Note{{Title: "Note", Mark: "1"}},
+			expect: `<table>
+<tr><th style="font-weight:bold">Title</th><th style="font-weight:bold">Mark</th></tr>
+<tr><td style="text-decoration:underline;font-size:14pt;border-bottom:2px solid #000000">Note</td><td style="vertical-align:super;transform:rotate(45deg)">1</td></tr>
+</table>
+`,
+		},
+		{
+			description: "table border",
+			options:     []Option{WithTableBorder("2px solid #000", "1px dotted #ccc")},
+			source:      testLines[:2],
+			expect: `<table>
+<tr><th style="font-weight:bold;border-top:2px solid #000000;border-right:1px dotted #cccccc;border-bottom:1px dotted #cccccc;border-left:2px solid #000000">Seq</th><th style="font-weight:bold;border-top:2px solid #000000;border-right:2px solid #000000;border-bottom:1px dotted #cccccc;border-left:1px dotted #cccccc">Cost</th></tr>
+<tr><td style="border-top:1px dotted #cccccc;border-right:1px dotted #cccccc;border-bottom:1px dotted #cccccc;border-left:2px solid #000000">1</td><td style="border-top:1px dotted #cccccc;border-right:2px solid #000000;border-bottom:1px dotted #cccccc;border-left:1px dotted #cccccc">2</td></tr>
+<tr><td style="border-top:1px dotted #cccccc;border-right:1px dotted #cccccc;border-bottom:2px solid #000000;border-left:2px solid #000000">2</td><td style="border-top:1px dotted #cccccc;border-right:2px solid #000000;border-bottom:2px solid #000000;border-left:1px dotted #cccccc">3</td></tr>
+</table>
+`,
+		},
+	}
+
+	for _, testCase := range testCases {
+		buffer := new(bytes.Buffer)
+		err := NewHTMLEncoder(buffer, testCase.options...).Encode(testCase.source)
+		if !assert.Nil(t, err, testCase.description) {
+			continue
+		}
+		assert.EqualValues(t, testCase.expect, buffer.String(), testCase.description)
+	}
+}
+
+func TestHTMLEncoder_Encode_progress(t *testing.T) {
+	buffer := new(bytes.Buffer)
+	var written []string
+	progress := func(sheet string, rows int) {
+		written = append(written, buffer.String())
+	}
+	err := NewHTMLEncoder(buffer, WithProgress(progress)).Encode(testLines)
+	if !assert.Nil(t, err) {
+		return
+	}
+	header := "<table>\n<tr><th style=\"font-weight:bold\">Seq</th><th style=\"font-weight:bold\">Cost</th></tr>\n"
+	assert.EqualValues(t, []string{
+		"",
+		header + "<tr><td>1</td><td>2</td></tr>\n",
+		header + "<tr><td>1</td><td>2</td></tr>\n<tr><td>2</td><td>3</td></tr>\n",
+	}, written)
+	assert.EqualValues(t, header+"<tr><td>1</td><td>2</td></tr>\n<tr><td>2</td><td>3</td></tr>\n<tr><td>3</td><td>4</td></tr>\n</table>\n", buffer.String())
+}
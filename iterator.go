@@ -0,0 +1,128 @@
+package xlsy
+
+import (
+	"errors"
+	"fmt"
+	"github.com/viant/xunsafe"
+	"io"
+	"reflect"
+	"unsafe"
+)
+
+var errorType = reflect.TypeOf((*error)(nil)).Elem()
+
+// sourceRecordType returns record type of iterator func(yield func(T) bool), receive channel or Next() (T, error) source, or nil
+func sourceRecordType(sourceType reflect.Type) reflect.Type {
+	var recordType reflect.Type
+	if method, ok := sourceType.MethodByName("Next"); ok {
+		if methodType := method.Type; methodType.NumIn() == 1 && methodType.NumOut() == 2 && methodType.Out(1) == errorType {
+			recordType = methodType.Out(0)
+		}
+	}
+	switch sourceType.Kind() {
+	case reflect.Chan:
+		if sourceType.ChanDir()&reflect.RecvDir != 0 {
+			recordType = sourceType.Elem()
+		}
+	case reflect.Func:
+		if sourceType.NumIn() == 1 && sourceType.NumOut() == 0 {
+			if yield := sourceType.In(0); yield.Kind() == reflect.Func && yield.NumIn() == 1 && yield.NumOut() == 1 && yield.Out(0).Kind() == reflect.Bool {
+				recordType = yield.In(0)
+			}
+		}
+	}
+	if recordType == nil || ensureStruct(recordType) == nil || recordType.Kind() == reflect.Slice {
+		return nil
+	}
+	return recordType
+}
+
+// forEachRecord calls visitor with each slice, iterator, channel or Next() source record
+func (t *Table) forEachRecord(source interface{}, visitor func(index int, recordPtr unsafe.Pointer) error) error {
+	sourceValue := reflect.ValueOf(source)
+	if !sourceValue.IsValid() {
+		return nil
+	}
+	if sourceRecordType(sourceValue.Type()) == nil {
+		return t.forEachSliceRecord(source, visitor)
+	}
+	t.Cardinality = 0
+	index := 0
+	visit := func(record reflect.Value) error {
+		if record.Kind() == reflect.Ptr {
+			if record.IsNil() {
+				return nil
+			}
+		} else {
+			recordPtr := reflect.New(record.Type())
+			recordPtr.Elem().Set(record)
+			record = recordPtr
+		}
+		recordIndex := index
+		index++
+		t.Cardinality = index
+		return visitor(recordIndex, record.UnsafePointer())
+	}
+	if next := sourceValue.MethodByName("Next"); next.IsValid() {
+		for {
+			out := next.Call(nil)
+			if err, _ := out[1].Interface().(error); err != nil {
+				if errors.Is(err, io.EOF) {
+					return nil
+				}
+				return err
+			}
+			if out[0].Kind() == reflect.Ptr && out[0].IsNil() {
+				return nil
+			}
+			if err := visit(out[0]); err != nil {
+				return err
+			}
+		}
+	}
+	switch sourceValue.Kind() {
+	case reflect.Chan:
+		for {
+			record, ok := sourceValue.Recv()
+			if !ok {
+				return nil
+			}
+			if err := visit(record); err != nil {
+				return err
+			}
+		}
+	case reflect.Func:
+		var err error
+		yield := reflect.MakeFunc(sourceValue.Type().In(0), func(args []reflect.Value) []reflect.Value {
+			if err == nil { //iterator ignoring false keeps calling yield, the first error is kept
+				err = visit(args[0])
+			}
+			return []reflect.Value{reflect.ValueOf(err == nil)}
+		})
+		sourceValue.Call([]reflect.Value{yield})
+		return err
+	}
+	return fmt.Errorf("unsupported record source: %T", source)
+}
+
+func (t *Table) forEachSliceRecord(source interface{}, visitor func(index int, recordPtr unsafe.Pointer) error) error {
+	sliceType := ensureSlice(t.Type)
+	xSlice := xunsafe.NewSlice(sliceType)
+	ptr := xunsafe.AsPointer(source)
+	sliceLen := xSlice.Len(ptr)
+	t.Cardinality = sliceLen
+	for sliceIndex := 0; sliceIndex < sliceLen; sliceIndex++ {
+		record := xSlice.ValueAt(ptr, sliceIndex)
+		if record == nil {
+			continue
+		}
+		recordPtr := xunsafe.AsPointer(record)
+		if recordPtr == nil {
+			continue
+		}
+		if err := visitor(sliceIndex, recordPtr); err != nil {
+			return err
+		}
+	}
+	return nil
+}
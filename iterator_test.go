@@ -0,0 +1,117 @@
+package xlsy
+
+import (
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"testing"
+	"unsafe"
+)
+
+type testRecord struct {
+	ID   int
+	Name string
+}
+
+type testCursor struct {
+	records []*testRecord
+	index   int
+}
+
+func (c *testCursor) Next() (*testRecord, error) {
+	if c.index >= len(c.records) {
+		return nil, io.EOF
+	}
+	c.index++
+	return c.records[c.index-1], nil
+}
+
+func TestMarshaller_Marshal_recordSource(t *testing.T) {
+	records := []*testRecord{{ID: 1, Name: "name 1"}, {ID: 2, Name: "name 2"}, {ID: 3, Name: "name 3"}}
+
+	var testCases = []struct {
+		description string
+		source      func() interface{}
+		options     []Option
+	}{
+		{
+			description: "iterator",
+			source: func() interface{} {
+				return func(yield func(*testRecord) bool) {
+					for _, record := range records {
+						if !yield(record) {
+							return
+						}
+					}
+				}
+			},
+		},
+		{
+			description: "channel",
+			source: func() interface{} {
+				ch := make(chan testRecord, len(records))
+				for _, record := range records {
+					ch <- *record
+				}
+				close(ch)
+				return (<-chan testRecord)(ch)
+			},
+		},
+		{
+			description: "next cursor",
+			source: func() interface{} {
+				return &testCursor{records: records}
+			},
+		},
+		{
+			description: "streamed iterator",
+			source: func() interface{} {
+				return func(yield func(*testRecord) bool) {
+					for _, record := range records {
+						if !yield(record) {
+							return
+						}
+					}
+				}
+			},
+			options: []Option{WithStreaming()},
+		},
+		{
+			description: "streamed next cursor",
+			source: func() interface{} {
+				return &testCursor{records: records}
+			},
+			options: []Option{WithStreaming()},
+		},
+	}
+
+	for _, testCase := range testCases {
+		data, err := NewMarshaller(testCase.options...).Marshal(testCase.source())
+		if !assert.Nil(t, err, testCase.description) {
+			continue
+		}
+		var actual []*testRecord
+		err = NewUnmarshaller().Unmarshal(data, &actual)
+		if !assert.Nil(t, err, testCase.description) {
+			continue
+		}
+		assert.EqualValues(t, records, actual, testCase.description)
+	}
+}
+
+func TestTable_forEachRecord(t *testing.T) {
+	records := []*testRecord{{ID: 1, Name: "name 1"}, {ID: 2, Name: "name 2"}, {ID: 3, Name: "name 3"}}
+	var visited []int
+	//iterator ignoring yield result keeps pushing records after the visitor error
+	iterator := func(yield func(*testRecord) bool) {
+		for _, record := range records {
+			yield(record)
+		}
+	}
+	err := (&Table{}).forEachRecord(iterator, func(index int, recordPtr unsafe.Pointer) error {
+		visited = append(visited, index)
+		return fmt.Errorf("invalid record: %v", index)
+	})
+	assert.EqualError(t, err, "invalid record: 0")
+	assert.EqualValues(t, []int{0}, visited)
+}
@@ -0,0 +1,193 @@
+package xlsy
+
+import (
+	"context"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// TextEncoder represents markdown or aligned plain text tables encoder, nested relations are rendered as indented sub-tables
+type TextEncoder struct {
+	writer     io.Writer
+	markdown   bool
+	marshaller *Marshaller
+}
+
+// Encode encodes arbitrary type as text tables
+func (e *TextEncoder) Encode(any interface{}) error {
+	return e.EncodeContext(context.Background(), any)
+}
+
+// EncodeContext encodes arbitrary type as text tables, cancellation is checked between records
+func (e *TextEncoder) EncodeContext(ctx context.Context, any interface{}) error {
+	sheets, err := e.marshaller.textSheets(ctx, any)
+	if err != nil {
+		return err
+	}
+	builder := &strings.Builder{}
+	for i, aSheet := range sheets {
+		if i > 0 {
+			builder.WriteString("\n")
+		}
+		if len(sheets) > 1 {
+			e.writeTitle(builder, aSheet.name)
+		}
+		for j, table := range aSheet.tables {
+			if j > 0 {
+				builder.WriteString("\n")
+			}
+			e.writeTable(builder, table, table.Rows, "")
+		}
+	}
+	_, err = io.WriteString(e.writer, builder.String())
+	return err
+}
+
+func (e *TextEncoder) writeTitle(builder *strings.Builder, title string) {
+	if e.markdown {
+		builder.WriteString("## " + title + "\n\n")
+		return
+	}
+	builder.WriteString(title + "\n" + strings.Repeat("=", int(textWidth(title))) + "\n\n")
+}
+
+// writeTable writes table leaf columns followed by nested relations of each row
+func (e *TextEncoder) writeTable(builder *strings.Builder, table *Table, rows Rows, indent string) {
+	var columns []int
+	if table.Header != nil {
+		for i := range table.Header.Values {
+			column := table.columnByIndex(i)
+			if column.Table != nil || column.Tag.Blank || !table.isVisible(i, column) {
+				continue
+			}
+			columns = append(columns, i)
+		}
+	}
+	lines := make([][]string, 1, len(rows)+1)
+	for _, index := range columns {
+		lines[0] = append(lines[0], table.columnByIndex(index).Name)
+	}
+	for _, row := range rows {
+		line := make([]string, len(columns))
+		for i, index := range columns {
+			if index >= len(row.Values) || !row.Values[index].hasValue {
+				continue
+			}
+			line[i] = table.columnByIndex(index).text(table.Stylizer, row.Values[index].value)
+		}
+		lines = append(lines, line)
+	}
+	if table.Invert() {
+		lines = transposeLines(lines)
+	}
+	if len(columns) > 0 {
+		if e.markdown {
+			writeMarkdownLines(builder, lines, indent)
+		} else {
+			writePlainLines(builder, lines, indent)
+		}
+	}
+	e.writeRelations(builder, table, rows, indent)
+}
+
+// writeRelations writes nested tables rows of each record as labeled sub-tables
+func (e *TextEncoder) writeRelations(builder *strings.Builder, table *Table, rows Rows, indent string) {
+	if table.Header == nil {
+		return
+	}
+	nestedIndent := indent + "  "
+	if e.markdown {
+		nestedIndent = indent + "> "
+	}
+	for r, row := range rows {
+		for i := range table.Header.Values {
+			column := table.columnByIndex(i)
+			if column.Table == nil || !table.isVisible(i, column) || i >= len(row.Values) || len(row.Values[i].rows) == 0 {
+				continue
+			}
+			name := column.Name
+			if name == "" {
+				name = column.Field.Name
+			}
+			builder.WriteString(strings.TrimRight(indent, " ") + "\n")
+			builder.WriteString(nestedIndent + name + " (row " + strconv.Itoa(r+1) + "):\n")
+			if e.markdown {
+				builder.WriteString(strings.TrimRight(nestedIndent, " ") + "\n")
+			}
+			e.writeTable(builder, column.Table, row.Values[i].rows, nestedIndent)
+		}
+	}
+}
+
+func transposeLines(lines [][]string) [][]string {
+	if len(lines) == 0 {
+		return lines
+	}
+	ret := make([][]string, len(lines[0]))
+	for i := range ret {
+		ret[i] = make([]string, len(lines))
+		for j, line := range lines {
+			ret[i][j] = line[i]
+		}
+	}
+	return ret
+}
+
+func writeMarkdownLines(builder *strings.Builder, lines [][]string, indent string) {
+	for i, line := range lines {
+		cells := make([]string, len(line))
+		for j, cell := range line {
+			cell = strings.ReplaceAll(cell, "|", `\|`)
+			cells[j] = strings.ReplaceAll(cell, "\n", " ")
+		}
+		builder.WriteString(indent + "| " + strings.Join(cells, " | ") + " |\n")
+		if i == 0 {
+			builder.WriteString(indent + "|" + strings.Repeat(" --- |", len(line)) + "\n")
+		}
+	}
+}
+
+func writePlainLines(builder *strings.Builder, lines [][]string, indent string) {
+	var widths []int
+	for _, line := range lines {
+		for j, cell := range line {
+			if j >= len(widths) {
+				widths = append(widths, 0)
+			}
+			if width := int(textWidth(cell)); width > widths[j] {
+				widths[j] = width
+			}
+		}
+	}
+	writeLine := func(line []string) {
+		text := indent
+		for j, cell := range line {
+			if j > 0 {
+				text += "  "
+			}
+			text += cell + strings.Repeat(" ", widths[j]-int(textWidth(cell)))
+		}
+		builder.WriteString(strings.TrimRight(text, " ") + "\n")
+	}
+	for i, line := range lines {
+		writeLine(line)
+		if i == 0 {
+			separator := make([]string, len(widths))
+			for j, width := range widths {
+				separator[j] = strings.Repeat("-", width)
+			}
+			writeLine(separator)
+		}
+	}
+}
+
+// NewMarkdownEncoder creates markdown tables encoder with options
+func NewMarkdownEncoder(writer io.Writer, opts ...Option) *TextEncoder {
+	return &TextEncoder{writer: writer, markdown: true, marshaller: NewMarshaller(opts...)}
+}
+
+// NewTextEncoder creates aligned plain text tables encoder with options
+func NewTextEncoder(writer io.Writer, opts ...Option) *TextEncoder {
+	return &TextEncoder{writer: writer, marshaller: NewMarshaller(opts...)}
+}
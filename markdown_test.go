@@ -0,0 +1,140 @@
+package xlsy
+
+import (
+	"bytes"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"testing"
+)
+
+func TestTextEncoder_Encode(t *testing.T) {
+	type Item struct {
+		Seq     int
+		Product string
+	}
+	type Order struct {
+		ID     int     `xls:"name=Id"`
+		Note   string  `xls:"-"`
+		Amount float64 `xls:"style={format:usd}"`
+		Items  []*Item
+		Info   string
+	}
+	type Summary struct {
+		Report string
+		Total  float64
+	}
+	type Holder struct {
+		Orders  []*Order
+		Summary Summary `xls:"worksheet=Totals"`
+	}
+	type Sales struct {
+		Summary []Summary `xls:"worksheet=売上"`
+		Total   Summary   `xls:"worksheet=Total"`
+	}
+	orders := []*Order{
+		{ID: 1, Amount: 1234.5, Items: []*Item{{Seq: 1, Product: "P1"}, {Seq: 2, Product: "P|2"}}, Info: "info 1"},
+		{ID: 2, Amount: 3, Info: "info 2"},
+	}
+
+	var testCases = []struct {
+		description string
+		encoder     func(writer io.Writer) *TextEncoder
+		source      interface{}
+		expect      string
+	}{
+		{
+			description: "plain text",
+			encoder:     func(writer io.Writer) *TextEncoder { return NewTextEncoder(writer) },
+			source:      orders,
+			expect: `Id  Amount     Info
+--  ---------  ------
+1   $1,234.50  info 1
+2   $3.00      info 2
+
+  Items (row 1):
+  Seq  Product
+  ---  -------
+  1    P1
+  2    P|2
+`,
+		},
+		{
+			description: "markdown",
+			encoder:     func(writer io.Writer) *TextEncoder { return NewMarkdownEncoder(writer) },
+			source:      orders,
+			expect: `| Id | Amount | Info |
+| --- | --- | --- |
+| 1 | $1,234.50 | info 1 |
+| 2 | $3.00 | info 2 |
+
+> Items (row 1):
+>
+> | Seq | Product |
+> | --- | --- |
+> | 1 | P1 |
+> | 2 | P\|2 |
+`,
+		},
+		{
+			description: "inverted plain text",
+			encoder:     func(writer io.Writer) *TextEncoder { return NewTextEncoder(writer, WithInverted()) },
+			source:      orders,
+			expect: `Id      1          2
+------  ---------  ------
+Amount  $1,234.50  $3.00
+Info    info 1     info 2
+
+  Items (row 1):
+  Seq      1   2
+  -------  --  ---
+  Product  P1  P|2
+`,
+		},
+		{
+			description: "plain text east asian width",
+			encoder:     func(writer io.Writer) *TextEncoder { return NewTextEncoder(writer) },
+			source:      &Sales{Summary: []Summary{{Report: "合計", Total: 1}, {Report: "ab", Total: 2}}, Total: Summary{Report: "x", Total: 3}},
+			expect: `売上
+====
+
+Report  Total
+------  -----
+合計    1
+ab      2
+
+Total
+=====
+
+Report  Total
+------  -----
+x       3
+`,
+		},
+		{
+			description: "markdown worksheets",
+			encoder:     func(writer io.Writer) *TextEncoder { return NewMarkdownEncoder(writer) },
+			source:      &Holder{Orders: orders[1:], Summary: Summary{Report: "Total", Total: 11.2}},
+			expect: `## Orders
+
+| Id | Amount | Info |
+| --- | --- | --- |
+| 2 | $3.00 | info 2 |
+
+## Totals
+
+| Report | Total |
+| --- | --- |
+| Total | 11.2 |
+`,
+		},
+	}
+
+	for _, testCase := range testCases {
+		buffer := new(bytes.Buffer)
+		err := testCase.encoder(buffer).Encode(testCase.source)
+		if !assert.Nil(t, err, testCase.description) {
+			continue
+		}
+		assert.EqualValues(t, testCase.expect, buffer.String(), testCase.description)
+	}
+}
@@ -2,9 +2,12 @@ package xlsy
 
 import (
 	bytes "bytes"
+	"context"
+	"database/sql"
 	"fmt"
 	"github.com/viant/xunsafe"
 	"github.com/xuri/excelize/v2"
+	"io"
 	"reflect"
 	"sort"
 	"unsafe"
@@ -17,50 +20,118 @@ type Marshaller struct {
 
 // Marshal marshall arbitrary type to xls
 func (m *Marshaller) Marshal(any interface{}) ([]byte, error) {
+	return m.MarshalContext(context.Background(), any)
+}
+
+// MarshalContext marshall arbitrary type to xls, cancellation is checked between records and rows transfer
+func (m *Marshaller) MarshalContext(ctx context.Context, any interface{}) ([]byte, error) {
+	buffer := new(bytes.Buffer)
+	if err := m.write(ctx, any, buffer); err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
+
+// write marshall arbitrary type to xls writer
+func (m *Marshaller) write(ctx context.Context, any interface{}, writer io.Writer) error {
 	dest := excelize.NewFile()
+	defer dest.Close() //close after write, it releases stream writers data
+	aSession, sheet, source, err := m.build(ctx, any, dest)
+	if err != nil {
+		return err
+	}
+	for _, name := range aSession.names {
+		item := aSession.sheets[name]
+		if aSession.ods { //ods content is read back from transferred cells
+			err = m.setSourceData(ctx, item)
+		} else {
+			err = m.ensureTableData(ctx, item)
+		}
+		if err != nil {
+			return err
+		}
+		if err := item.transfer(); err != nil {
+			return err
+		}
+		if sheet == nil || sheet.index == nil {
+			sheet = aSession.sheets[name]
+		}
+	}
+	if sheet != nil {
+		m.deleteDefaultWorksheetIfNeeded(sheet)
+		sheet.SetActiveSheet()
+	}
+
+	if source != nil && source.err != nil {
+		return source.err
+	}
+	if aSession.ods {
+		return m.writeODS(aSession, writer)
+	}
+	return dest.Write(writer)
+}
+
+// build creates session worksheets with tables for arbitrary type, returned rows source is not nil for *sql.Rows
+func (m *Marshaller) build(ctx context.Context, any interface{}, dest *excelize.File) (*session, *workSheet, *rowsSource, error) {
 	rawType := reflect.TypeOf(any)
 	stylizer := &Stylizer{registry: map[string]*Style{}, file: dest}
 	aSession := newSession(nil, stylizer, NewTag())
 	err := aSession.apply(m.session)
 	if err != nil {
-		return nil, err
+		return nil, nil, nil, err
+	}
+	aSession.ctx = ctx
+	var source *rowsSource
+	if rows, ok := any.(*sql.Rows); ok {
+		if source, err = newRowsSource(rows, aSession); err != nil {
+			return nil, nil, nil, err
+		}
+		aSession.rows = source
+		any = source.iterator()
+		rawType = reflect.TypeOf(any)
+	}
+	var sheet *workSheet
+	if recordType := sourceRecordType(rawType); recordType != nil {
+		rawType = reflect.SliceOf(recordType)
 	}
 	if rawType.Kind() == reflect.Ptr {
 		rawType = rawType.Elem()
 	}
-	var sheet *workSheet
 	switch rawType.Kind() {
 	case reflect.Slice:
 		sheet, err = m.buildSheet(any, rawType, aSession)
 	case reflect.Struct:
 		sheet, err = m.buildSheets(any, rawType, aSession)
 	default:
-		return nil, fmt.Errorf("unsupported type: %T", any)
+		return nil, nil, nil, fmt.Errorf("unsupported type: %T", any)
 	}
 	if err != nil {
-		return nil, err
+		return nil, nil, nil, err
 	}
+	return aSession, sheet, source, nil
+}
 
-	for _, name := range aSession.names {
-		item := aSession.sheets[name]
-		if err := item.transfer(); err != nil {
-			return nil, err
-		}
-		if sheet == nil || sheet.index == nil {
-			sheet = aSession.sheets[name]
-		}
-	}
-	if sheet != nil {
-		m.deleteDefaultWorksheetIfNeeded(sheet)
-		sheet.SetActiveSheet()
+// ensureTableData sets rows of streamed tables sharing a worksheet with other tables
+func (m *Marshaller) ensureTableData(ctx context.Context, aSheet *workSheet) error {
+	if len(aSheet.tables) < 2 {
+		return nil
 	}
+	return m.setSourceData(ctx, aSheet)
+}
 
-	err = dest.Close()
-	buffer := new(bytes.Buffer)
-	if err = dest.Write(buffer); err != nil {
-		return nil, err
+// setSourceData sets rows of all streamed sheet tables
+func (m *Marshaller) setSourceData(ctx context.Context, aSheet *workSheet) error {
+	for _, table := range aSheet.tables {
+		if table.source == nil {
+			continue
+		}
+		source := table.source
+		table.source = nil
+		if err := m.setTableData(ctx, source, table); err != nil {
+			return err
+		}
 	}
-	return buffer.Bytes(), err
+	return nil
 }
 
 func (m *Marshaller) deleteDefaultWorksheetIfNeeded(aSheet *workSheet) {
@@ -75,21 +146,12 @@ func (m *Marshaller) deleteDefaultWorksheetIfNeeded(aSheet *workSheet) {
 }
 
 func (m *Marshaller) buildSheets(v any, structType reflect.Type, parent *session) (*workSheet, error) {
-	xStruct := xunsafe.NewStruct(structType)
 	ptr := xunsafe.AsPointer(v)
 	var aSheet *workSheet
 
-	var fields = xStruct.Fields
-	sort.Slice(fields, func(i, j int) bool {
-		posI := sheetPos(&xStruct.Fields[i])
-		posJ := sheetPos(&xStruct.Fields[j])
-		if posI == posJ {
-			return xStruct.Fields[i].Index < xStruct.Fields[j].Index
-		}
-		return posI < posJ
-	})
+	var fields = sheetFields(structType)
 	for i := range fields {
-		field := &xStruct.Fields[i]
+		field := &fields[i]
 		fieldType := field.Type
 		if fieldType.Kind() == reflect.Ptr {
 			fieldType = fieldType.Elem()
@@ -127,6 +189,21 @@ func (m *Marshaller) buildSheets(v any, structType reflect.Type, parent *session
 	return aSheet, nil
 }
 
+// sheetFields returns struct fields ordered by sheet position
+func sheetFields(structType reflect.Type) []xunsafe.Field {
+	xStruct := xunsafe.NewStruct(structType)
+	var fields = xStruct.Fields
+	sort.Slice(fields, func(i, j int) bool {
+		posI := sheetPos(&fields[i])
+		posJ := sheetPos(&fields[j])
+		if posI == posJ {
+			return fields[i].Index < fields[j].Index
+		}
+		return posI < posJ
+	})
+	return fields
+}
+
 func sheetPos(field *xunsafe.Field) int {
 	if tag, _ := parseTag(field.Tag); tag != nil {
 		return tag.SheetPos
@@ -148,17 +225,27 @@ func (m *Marshaller) buildSheet(v any, tableType reflect.Type, aSession *session
 		return nil, err
 	}
 	aSheet.addTable(aTable)
+	if aSession.rows != nil {
+		aSession.rows.setColumnNames(aTable)
+	}
 	if err = m.setTableHeader(aTable, aSession); err != nil {
 		return nil, err
 	}
-	if err = m.setTableData(v, aTable); err != nil {
+	if aSession.isStreaming() && aTable.IsFlat() {
+		if err = aTable.checkStreaming(); err != nil {
+			return nil, err
+		}
+		aTable.source = v //rows are written during transfer
+		return aSheet, nil
+	}
+	if err = m.setTableData(aSession.ctx, v, aTable); err != nil {
 		return nil, err
 	}
 
 	return aSheet, nil
 }
 
-func (m *Marshaller) setTableData(v any, aTable *Table) error {
+func (m *Marshaller) setTableData(ctx context.Context, v any, aTable *Table) error {
 	if aTable.IsStruct {
 		if v == nil {
 			return nil
@@ -167,33 +254,25 @@ func (m *Marshaller) setTableData(v any, aTable *Table) error {
 		if ptr == nil {
 			return nil
 		}
-		return m.setRecord(aTable, 0, ptr)
+		return m.setRecord(ctx, aTable, 0, ptr)
 	}
 
-	sliceType := ensureSlice(aTable.Type)
-
-	xSlice := xunsafe.NewSlice(sliceType)
-	ptr := xunsafe.AsPointer(v)
-	sliceLen := xSlice.Len(ptr)
-	aTable.Cardinality = sliceLen
-	for sliceIndex := 0; sliceIndex < sliceLen; sliceIndex++ {
-		record := xSlice.ValueAt(ptr, sliceIndex)
-		if record == nil {
-			continue
-		}
-		recordPtr := xunsafe.AsPointer(record)
-		if recordPtr == nil {
-			continue
-		}
-		err := m.setRecord(aTable, sliceIndex, recordPtr)
-		if err != nil {
+	return aTable.forEachRecord(v, func(index int, recordPtr unsafe.Pointer) error {
+		if err := ctx.Err(); err != nil {
 			return err
 		}
-	}
-	return nil
+		return m.setRecord(ctx, aTable, index, recordPtr)
+	})
 }
 
-func (m *Marshaller) setRecord(aTable *Table, sliceIndex int, recordPtr unsafe.Pointer) error {
+func (m *Marshaller) setRecord(ctx context.Context, aTable *Table, sliceIndex int, recordPtr unsafe.Pointer) error {
+	rowStyleID, err := aTable.rowStyleID(sliceIndex, recordPtr)
+	if err != nil {
+		return err
+	}
+	if rowStyleID != 0 {
+		aTable.Rows.index(sliceIndex).StyleID = rowStyleID
+	}
 	columnOffset := 0
 	for i := 0; i < len(aTable.Columns); i++ {
 		column := aTable.Columns[i]
@@ -208,14 +287,20 @@ func (m *Marshaller) setRecord(aTable *Table, sliceIndex int, recordPtr unsafe.P
 		xField := column.Field
 		value := xField.Value(recordPtr)
 		if column.Table != nil {
-			if err := m.setTableData(value, column.Table); err != nil {
-				return err
+			if column.Table.IsStandalone() {
+				if err := m.setTableData(ctx, value, column.Table); err != nil {
+					return err
+				}
+				continue
 			}
-			if !column.Table.IsStandalone() {
-				cell := row.Values.index(columnOffset)
-				cell.rows = column.Table.Rows
-				columnOffset++
+			column.Table.Rows = nil //each parent cell owns its nested rows
+			if err := m.setTableData(ctx, value, column.Table); err != nil {
+				return err
 			}
+			cell := row.Values.index(columnOffset)
+			cell.rows = column.Table.Rows
+			column.Table.Rows = nil
+			columnOffset++
 			continue
 		}
 		cell := row.Values.index(columnOffset)
@@ -226,15 +311,7 @@ func (m *Marshaller) setRecord(aTable *Table, sliceIndex int, recordPtr unsafe.P
 			column.Table.Rows = nil
 			continue
 		}
-		isNil := false
-		if xField.Kind() == reflect.Ptr {
-			if (*unsafe.Pointer)(xunsafe.AsPointer(value)) != nil {
-				value = column.xType.Deref(value)
-			} else {
-				isNil = true
-			}
-		}
-		if !isNil {
+		if value, ok := column.recordValue(recordPtr); ok {
 			cell.setValue(value)
 		}
 		if styleID := column.CellStyleID(aTable.Stylizer); styleID != nil {
@@ -0,0 +1,68 @@
+package xlsy
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestMarshaller_MarshalContext(t *testing.T) {
+	records := []*testRecord{{ID: 1, Name: "name 1"}, {ID: 2, Name: "name 2"}, {ID: 3, Name: "name 3"}}
+
+	var testCases = []struct {
+		description string
+		options     []Option
+		cancelAfter int
+		expectRows  map[string]int
+		expectErr   error
+	}{
+		{
+			description: "progress",
+			expectRows:  map[string]int{defaultSheetName: 3},
+		},
+		{
+			description: "streamed progress",
+			options:     []Option{WithStreaming()},
+			expectRows:  map[string]int{defaultSheetName: 3},
+		},
+		{
+			description: "cancelled",
+			cancelAfter: 1,
+			expectRows:  map[string]int{defaultSheetName: 1},
+			expectErr:   context.Canceled,
+		},
+		{
+			description: "streamed cancelled",
+			options:     []Option{WithStreaming()},
+			cancelAfter: 2,
+			expectRows:  map[string]int{defaultSheetName: 2},
+			expectErr:   context.Canceled,
+		},
+	}
+
+	for _, testCase := range testCases {
+		ctx, cancel := context.WithCancel(context.Background())
+		actualRows := map[string]int{}
+		options := append(testCase.options, WithProgress(func(sheet string, rows int) {
+			actualRows[sheet] = rows
+			if rows == testCase.cancelAfter {
+				cancel()
+			}
+		}))
+		data, err := NewMarshaller(options...).MarshalContext(ctx, records)
+		cancel()
+		assert.EqualValues(t, testCase.expectRows, actualRows, testCase.description)
+		if testCase.expectErr != nil {
+			assert.ErrorIs(t, err, testCase.expectErr, testCase.description)
+			assert.Nil(t, data, testCase.description)
+			continue
+		}
+		if !assert.Nil(t, err, testCase.description) {
+			continue
+		}
+		var actual []*testRecord
+		if err = NewUnmarshaller().Unmarshal(data, &actual); assert.Nil(t, err, testCase.description) {
+			assert.EqualValues(t, records, actual, testCase.description)
+		}
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/viant/afs"
 	"github.com/viant/afs/file"
 	"github.com/viant/tagly/format"
+	"github.com/xuri/excelize/v2"
 	"os"
 	"path"
 	"testing"
@@ -211,6 +212,97 @@ func TestMarshaller_Marshal(t *testing.T) {
 	}
 }
 
+func TestMarshaller_Marshal_nested(t *testing.T) {
+	type Line struct {
+		Seq  int
+		Cost float64
+	}
+	type Order struct {
+		ID    int
+		Lines []Line
+	}
+	type Shipment struct {
+		ID    int
+		Note  string `xls:"-"`
+		Lines []Line
+		Total float64 `xls:"style={format:'0.00'}"`
+	}
+
+	var testCases = []struct {
+		description string
+		source      interface{}
+		expect      [][]string
+	}{
+		{
+			description: "parents own nested rows",
+			source:      []Order{{ID: 1, Lines: []Line{{Seq: 1, Cost: 2}, {Seq: 2, Cost: 3}}}, {ID: 2, Lines: []Line{{Seq: 3, Cost: 4}}}, {ID: 3}},
+			expect: [][]string{
+				{"ID", "Lines"},
+				{"", "Seq", "Cost"},
+				{"1", "1", "2"},
+				{"", "2", "3"},
+				{"2", "3", "4"},
+				{"3"},
+			},
+		},
+		{
+			description: "nested header without nested rows",
+			source:      []Order{{ID: 1}},
+			expect:      [][]string{{"ID", "Lines"}, {"", "Seq", "Cost"}, {"1"}},
+		},
+		{
+			description: "empty table header",
+			source:      []Order{},
+			expect:      [][]string{{"ID", "Lines"}, {"", "Seq", "Cost"}},
+		},
+		{
+			description: "header index mapped to column after ignored column",
+			source:      []Shipment{{ID: 1, Note: "n", Lines: []Line{{Seq: 1, Cost: 2}}, Total: 2}},
+			expect:      [][]string{{"ID", "Lines", "", "Total"}, {"", "Seq", "Cost"}, {"1", "1", "2", "2.00"}},
+		},
+	}
+
+	for _, testCase := range testCases {
+		data, err := NewMarshaller().Marshal(testCase.source)
+		if !assert.Nil(t, err, testCase.description) {
+			continue
+		}
+		file, err := excelize.OpenReader(bytes.NewReader(data))
+		if !assert.Nil(t, err, testCase.description) {
+			continue
+		}
+		rows, err := file.GetRows(file.GetSheetName(0))
+		assert.Nil(t, err, testCase.description)
+		assert.EqualValues(t, testCase.expect, rows, testCase.description)
+		_ = file.Close()
+	}
+}
+
 func intPtr(i int) *int {
 	return &i
 }
+
+type (
+	testLine struct {
+		Seq  int
+		Cost float64
+	}
+	testOrder struct {
+		ID    int
+		Lines []testLine
+	}
+)
+
+var (
+	testLines  = []testLine{{Seq: 1, Cost: 2}, {Seq: 2, Cost: 3}, {Seq: 3, Cost: 4}}
+	testOrders = []testOrder{{ID: 1, Lines: testLines[:2]}, {ID: 2, Lines: testLines[2:]}}
+)
+
+// marshalTestWorkbook marshals source and opens the resulting workbook
+func marshalTestWorkbook(source interface{}, options ...Option) (*excelize.File, error) {
+	data, err := NewMarshaller(options...).Marshal(source)
+	if err != nil {
+		return nil, err
+	}
+	return excelize.OpenReader(bytes.NewReader(data))
+}
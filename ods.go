@@ -0,0 +1,359 @@
+package xlsy
+
+import (
+	"archive/zip"
+	"encoding/xml"
+	"fmt"
+	"github.com/xuri/excelize/v2"
+	"io"
+	"strconv"
+	"strings"
+)
+
+const (
+	odsMimeType  = "application/vnd.oasis.opendocument.spreadsheet"
+	odsNamespace = `xmlns:office="urn:oasis:names:tc:opendocument:xmlns:office:1.0" xmlns:style="urn:oasis:names:tc:opendocument:xmlns:style:1.0" ` +
+		`xmlns:text="urn:oasis:names:tc:opendocument:xmlns:text:1.0" xmlns:table="urn:oasis:names:tc:opendocument:xmlns:table:1.0" ` +
+		`xmlns:fo="urn:oasis:names:tc:opendocument:xmlns:xsl-fo-compatible:1.0" xmlns:number="urn:oasis:names:tc:opendocument:xmlns:datastyle:1.0" office:version="1.2"`
+	odsManifest = `<?xml version="1.0" encoding="UTF-8"?>
+<manifest:manifest xmlns:manifest="urn:oasis:names:tc:opendocument:xmlns:manifest:1.0" manifest:version="1.2">
+<manifest:file-entry manifest:full-path="/" manifest:version="1.2" manifest:media-type="` + odsMimeType + `"/>
+<manifest:file-entry manifest:full-path="content.xml" manifest:media-type="text/xml"/>
+<manifest:file-entry manifest:full-path="styles.xml" manifest:media-type="text/xml"/>
+</manifest:manifest>
+`
+	odsStyles = `<?xml version="1.0" encoding="UTF-8"?>
+<office:document-styles ` + odsNamespace + `><office:styles/></office:document-styles>
+`
+)
+
+// odsDocument represents OpenDocument spreadsheet content built from rendered worksheets
+type odsDocument struct {
+	styles      strings.Builder
+	tables      strings.Builder
+	cellStyles  map[int]string
+	dataStyles  map[string]string
+	columnStyle map[float64]string
+}
+
+// writeODS writes transferred worksheets as OpenDocument spreadsheet
+func (m *Marshaller) writeODS(aSession *session, writer io.Writer) error {
+	doc := &odsDocument{cellStyles: map[int]string{}, dataStyles: map[string]string{}, columnStyle: map[float64]string{}}
+	for _, name := range aSession.names {
+		aSheet := aSession.sheets[name]
+		if aSheet.index == nil {
+			continue
+		}
+		rendered, err := newRenderedSheet(aSheet, aSession.stylizer)
+		if err != nil {
+			return err
+		}
+		if err = doc.addTable(rendered); err != nil {
+			return err
+		}
+	}
+	if doc.tables.Len() == 0 { //spreadsheet requires at least one table
+		doc.tables.WriteString(`<table:table table:name="` + defaultSheetName + `"><table:table-column/><table:table-row><table:table-cell/></table:table-row></table:table>`)
+	}
+	archive := zip.NewWriter(writer)
+	mimeType, err := archive.CreateHeader(&zip.FileHeader{Name: "mimetype", Method: zip.Store})
+	if err != nil {
+		return err
+	}
+	if _, err = io.WriteString(mimeType, odsMimeType); err != nil {
+		return err
+	}
+	files := []struct {
+		name    string
+		content string
+	}{
+		{name: "META-INF/manifest.xml", content: odsManifest},
+		{name: "styles.xml", content: odsStyles},
+		{name: "content.xml", content: doc.content()},
+	}
+	for _, file := range files {
+		item, err := archive.Create(file.name)
+		if err != nil {
+			return err
+		}
+		if _, err = io.WriteString(item, file.content); err != nil {
+			return err
+		}
+	}
+	return archive.Close()
+}
+
+func (d *odsDocument) content() string {
+	return `<?xml version="1.0" encoding="UTF-8"?>
+<office:document-content ` + odsNamespace + `>
+<office:automatic-styles>` + d.styles.String() + `</office:automatic-styles>
+<office:body><office:spreadsheet>` + d.tables.String() + `</office:spreadsheet></office:body>
+</office:document-content>
+`
+}
+
+func (d *odsDocument) addTable(s *renderedSheet) error {
+	d.tables.WriteString(`<table:table table:name="` + xmlEscape(s.name) + `">`)
+	for column := 0; column < s.width; column++ {
+		width, ok, err := s.columnWidth(column)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			d.tables.WriteString(`<table:table-column/>`)
+			continue
+		}
+		d.tables.WriteString(`<table:table-column table:style-name="` + d.columnStyleName(width) + `"/>`)
+	}
+	for row := range s.rows {
+		d.tables.WriteString(`<table:table-row>`)
+		for column := 0; column < s.width; column++ {
+			cur := newCursor(row, column)
+			if s.covered[cur] {
+				d.tables.WriteString(`<table:covered-table-cell/>`)
+				continue
+			}
+			if err := d.addCell(s, cur); err != nil {
+				return err
+			}
+		}
+		d.tables.WriteString(`</table:table-row>`)
+	}
+	d.tables.WriteString(`</table:table>`)
+	return nil
+}
+
+func (d *odsDocument) addCell(s *renderedSheet, cur Cursor) error {
+	d.tables.WriteString(`<table:table-cell`)
+	styleID, style, err := s.cellStyle(cur)
+	if err != nil {
+		return err
+	}
+	if style != nil {
+		d.tables.WriteString(` table:style-name="` + d.cellStyleName(styleID, style) + `"`)
+	}
+	if columns, rows := s.span(cur); columns > 1 || rows > 1 {
+		d.tables.WriteString(` table:number-columns-spanned="` + strconv.Itoa(columns) + `" table:number-rows-spanned="` + strconv.Itoa(rows) + `"`)
+	}
+	text := s.cellText(cur, style)
+	if text == "" {
+		d.tables.WriteString(`/>`)
+		return nil
+	}
+	cellType, err := s.dest.GetCellType(s.name, cur.String())
+	if err != nil {
+		return err
+	}
+	raw := cellAt(s.raw, cur)
+	numFmt := style.numFormat()
+	switch {
+	case cellType == excelize.CellTypeBool:
+		d.tables.WriteString(` office:value-type="boolean" office:boolean-value="` + strconv.FormatBool(raw == "1") + `"`)
+	case cellType == excelize.CellTypeNumber || cellType == excelize.CellTypeUnset && isNumber(raw):
+		number, _ := strconv.ParseFloat(raw, 64)
+		switch {
+		case numFmt != "" && isDateFormat(numFmt):
+			ts, err := excelize.ExcelDateToTime(number, false)
+			if err != nil {
+				return err
+			}
+			d.tables.WriteString(` office:value-type="date" office:date-value="` + ts.Format("2006-01-02T15:04:05") + `"`)
+		case numFmt != "" && parseNumberFormat(numFmt) != nil && parseNumberFormat(numFmt).percent: //percentage value is a fraction
+			d.tables.WriteString(` office:value-type="percentage" office:value="` + raw + `"`)
+		default:
+			d.tables.WriteString(` office:value-type="float" office:value="` + raw + `"`)
+		}
+	default:
+		d.tables.WriteString(` office:value-type="string"`)
+	}
+	d.tables.WriteString(`><text:p>` + xmlEscape(text) + `</text:p></table:table-cell>`)
+	return nil
+}
+
+func (d *odsDocument) columnStyleName(width float64) string {
+	if name, ok := d.columnStyle[width]; ok {
+		return name
+	}
+	name := "co" + strconv.Itoa(len(d.columnStyle)+1)
+	d.columnStyle[width] = name
+	inches := width * pixelRatio / 96
+	d.styles.WriteString(`<style:style style:name="` + name + `" style:family="table-column"><style:table-column-properties style:column-width="` + strconv.FormatFloat(inches, 'f', 4, 64) + `in"/></style:style>`)
+	return name
+}
+
+// cellStyleName returns automatic cell style name for excelize style, fonts, fills, alignment and number formats are mapped
+func (d *odsDocument) cellStyleName(styleID int, style *extStyle) string {
+	if name, ok := d.cellStyles[styleID]; ok {
+		return name
+	}
+	name := "ce" + strconv.Itoa(styleID)
+	d.cellStyles[styleID] = name
+	dataStyle := ""
+	if numFmt := style.numFormat(); numFmt != "" {
+		dataStyle = ` style:data-style-name="` + d.dataStyleName(numFmt) + `"`
+	}
+	d.styles.WriteString(`<style:style style:name="` + name + `" style:family="table-cell"` + dataStyle + `>`)
+	if style.Style == nil {
+		d.styles.WriteString(`</style:style>`)
+		return name
+	}
+	var cell, paragraph, text []string
+	if fill := style.Fill; len(fill.Color) > 0 {
+		cell = append(cell, `fo:background-color="`+xmlEscape(fill.Color[0])+`"`)
+	}
+	for _, border := range style.Border {
+		cell = append(cell, `fo:border-`+border.Type+`="`+xmlEscape(odsBorder(border))+`"`)
+	}
+	if alignment := style.Alignment; alignment != nil {
+		switch alignment.Vertical {
+		case "top", "bottom":
+			cell = append(cell, `style:vertical-align="`+alignment.Vertical+`"`)
+		case "center":
+			cell = append(cell, `style:vertical-align="middle"`)
+		}
+		if alignment.WrapText {
+			cell = append(cell, `fo:wrap-option="wrap"`)
+		}
+		if alignment.ShrinkToFit {
+			cell = append(cell, `style:shrink-to-fit="true"`)
+		}
+		switch rotation := alignment.TextRotation; {
+		case rotation == 255:
+			cell = append(cell, `style:direction="ttb"`)
+		case rotation > 90:
+			cell = append(cell, `style:rotation-angle="`+strconv.Itoa(450-rotation)+`"`)
+		case rotation > 0:
+			cell = append(cell, `style:rotation-angle="`+strconv.Itoa(rotation)+`"`)
+		}
+		switch alignment.Horizontal {
+		case "left":
+			paragraph = append(paragraph, `fo:text-align="start"`)
+		case "right":
+			paragraph = append(paragraph, `fo:text-align="end"`)
+		case "center", "justify":
+			paragraph = append(paragraph, `fo:text-align="`+alignment.Horizontal+`"`)
+		}
+		if alignment.Indent > 0 {
+			paragraph = append(paragraph, fmt.Sprintf(`fo:margin-left="%vem"`, alignment.Indent))
+		}
+	}
+	if font := style.Font; font != nil {
+		if font.Bold {
+			text = append(text, `fo:font-weight="bold"`)
+		}
+		if font.Italic {
+			text = append(text, `fo:font-style="italic"`)
+		}
+		if font.Strike {
+			text = append(text, `style:text-line-through-style="solid"`)
+		}
+		switch font.Underline {
+		case "single":
+			text = append(text, `style:text-underline-style="solid"`, `style:text-underline-width="auto"`, `style:text-underline-color="font-color"`)
+		case "double":
+			text = append(text, `style:text-underline-style="solid"`, `style:text-underline-type="double"`, `style:text-underline-width="auto"`, `style:text-underline-color="font-color"`)
+		}
+		switch font.VertAlign {
+		case "superscript":
+			text = append(text, `style:text-position="super 58%"`)
+		case "subscript":
+			text = append(text, `style:text-position="sub 58%"`)
+		}
+		if font.Size > 0 && font.Size != defaultFontSize {
+			text = append(text, `fo:font-size="`+strconv.FormatFloat(font.Size, 'f', -1, 64)+`pt"`)
+		}
+		if font.Family != "" {
+			text = append(text, `fo:font-family="`+xmlEscape(font.Family)+`"`)
+		}
+		if font.Color != "" {
+			text = append(text, `fo:color="`+xmlEscape(font.Color)+`"`)
+		}
+	}
+	for _, properties := range []struct {
+		element    string
+		attributes []string
+	}{
+		{"style:table-cell-properties", cell},
+		{"style:paragraph-properties", paragraph},
+		{"style:text-properties", text},
+	} {
+		if len(properties.attributes) > 0 {
+			d.styles.WriteString(`<` + properties.element + ` ` + strings.Join(properties.attributes, " ") + `/>`)
+		}
+	}
+	d.styles.WriteString(`</style:style>`)
+	return name
+}
+
+// dataStyleName returns automatic data style name for excel number format
+func (d *odsDocument) dataStyleName(numFmt string) string {
+	if name, ok := d.dataStyles[numFmt]; ok {
+		return name
+	}
+	name := "N" + strconv.Itoa(len(d.dataStyles)+1)
+	d.dataStyles[numFmt] = name
+	if isDateFormat(numFmt) {
+		d.styles.WriteString(`<number:date-style style:name="` + name + `">`)
+		for _, token := range dateTokens(numFmt) {
+			long := ""
+			if token.long {
+				long = ` number:style="long"`
+			}
+			switch token.kind {
+			case "year", "day":
+				d.styles.WriteString(`<number:` + token.kind + long + `/>`)
+			case "month":
+				textual := ""
+				if token.textual {
+					textual = ` number:textual="true"`
+				}
+				d.styles.WriteString(`<number:month` + long + textual + `/>`)
+			case "hour", "minute", "second":
+				d.styles.WriteString(`<number:` + token.kind + `s` + long + `/>`)
+			default:
+				d.styles.WriteString(`<number:text>` + xmlEscape(token.text) + `</number:text>`)
+			}
+		}
+		d.styles.WriteString(`</number:date-style>`)
+		return name
+	}
+	format := parseNumberFormat(numFmt)
+	if format == nil {
+		d.styles.WriteString(`<number:text-style style:name="` + name + `"><number:text-content/></number:text-style>`)
+		return name
+	}
+	element := "number:number-style"
+	if format.percent {
+		element = "number:percentage-style"
+	}
+	d.styles.WriteString(`<` + element + ` style:name="` + name + `">`)
+	if format.prefix != "" {
+		d.styles.WriteString(`<number:text>` + xmlEscape(format.prefix) + `</number:text>`)
+	}
+	d.styles.WriteString(`<number:number number:decimal-places="` + strconv.Itoa(format.decimals) + `" number:min-integer-digits="1"`)
+	if format.grouping {
+		d.styles.WriteString(` number:grouping="true"`)
+	}
+	d.styles.WriteString(`/>`)
+	if format.suffix != "" {
+		d.styles.WriteString(`<number:text>` + xmlEscape(format.suffix) + `</number:text>`)
+	}
+	d.styles.WriteString(`</` + element + `>`)
+	return name
+}
+
+func isNumber(text string) bool {
+	_, err := strconv.ParseFloat(text, 64)
+	return err == nil
+}
+
+func xmlEscape(text string) string {
+	builder := &strings.Builder{}
+	_ = xml.EscapeText(builder, []byte(text))
+	return builder.String()
+}
+
+// odsBorder returns xsl-fo border definition, ODS uses pt widths
+func odsBorder(border excelize.Border) string {
+	return strings.Replace(strings.Replace(borderCSS(border), "3px", "2.5pt", 1), "px", "pt", 1)
+}
@@ -0,0 +1,115 @@
+package xlsy
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"testing"
+	"time"
+)
+
+func TestMarshaller_Marshal_ods(t *testing.T) {
+	type Item struct {
+		Seq     int
+		Product string
+	}
+	type Order struct {
+		ID      int       `xls:"name=Id,style={color:red;text-align:center;width:60px}"`
+		Amount  float64   `xls:"style={format:usd;background-color:yellow}"`
+		Ratio   float64   `xls:"style={format:pct}"`
+		Started time.Time `xls:"style={format:iso8601}"`
+		Items   []*Item
+		Active  bool
+	}
+	type Summary struct {
+		Report string
+		Total  float64
+	}
+	type Holder struct {
+		Orders  []*Order
+		Summary Summary `xls:"worksheet=Totals"`
+	}
+	type Empty struct {
+		Note string `xls:"-"`
+	}
+	orders := []*Order{
+		{ID: 1, Amount: 1234.5, Ratio: 0.5, Started: time.Date(2023, 8, 1, 10, 30, 0, 0, time.UTC), Items: []*Item{{Seq: 1, Product: "P1"}, {Seq: 2, Product: "P<2>"}}, Active: true},
+	}
+
+	var testCases = []struct {
+		description string
+		source      interface{}
+		expect      []string
+	}{
+		{
+			description: "styles, formats and merged header",
+			source:      orders,
+			expect: []string{
+				`<table:table table:name="Sheet1"><table:table-column table:style-name="co1"/>`,
+				`<style:table-column-properties style:column-width="0.6250in"/>`,
+				`<style:style style:name="ce1" style:family="table-cell"><style:paragraph-properties fo:text-align="center"/><style:text-properties fo:color="#ff0000"/></style:style>`,
+				`<number:number-style style:name="N1"><number:text>$</number:text><number:number number:decimal-places="2" number:min-integer-digits="1" number:grouping="true"/></number:number-style>`,
+				`<style:table-cell-properties fo:background-color="#ffff00"/>`,
+				`<number:date-style style:name="N3"><number:year number:style="long"/><number:text>/</number:text><number:month number:style="long"/>`,
+				`<table:table-cell table:number-columns-spanned="2" table:number-rows-spanned="1" office:value-type="string"><text:p>Items</text:p></table:table-cell><table:covered-table-cell/>`,
+				`office:value-type="float" office:value="1234.5"><text:p>$1,234.50</text:p>`,
+				`office:value-type="percentage" office:value="0.5"><text:p>50%</text:p>`,
+				`office:value-type="date" office:date-value="2023-08-01T10:30:00"><text:p>2023/08/01 10:30:00</text:p>`,
+				`office:value-type="boolean" office:boolean-value="true"`,
+				`<text:p>P&lt;2&gt;</text:p>`,
+			},
+		},
+		{
+			description: "worksheets",
+			source:      &Holder{Orders: orders, Summary: Summary{Report: "Total", Total: 11.2}},
+			expect: []string{
+				`<table:table table:name="Orders">`,
+				`<table:table table:name="Totals">`,
+				`<table:table-cell office:value-type="float" office:value="11.2"><text:p>11.2</text:p></table:table-cell>`,
+			},
+		},
+		{
+			description: "without tables",
+			source:      &Empty{},
+			expect: []string{
+				`<office:spreadsheet><table:table table:name="Sheet1"><table:table-column/><table:table-row><table:table-cell/></table:table-row></table:table></office:spreadsheet>`,
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		data, err := NewMarshaller(WithODS()).Marshal(testCase.source)
+		if !assert.Nil(t, err, testCase.description) {
+			continue
+		}
+		archive, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+		if !assert.Nil(t, err, testCase.description) {
+			continue
+		}
+		files := map[string]string{}
+		for _, file := range archive.File {
+			reader, err := file.Open()
+			if !assert.Nil(t, err, testCase.description) {
+				continue
+			}
+			content, _ := io.ReadAll(reader)
+			files[file.Name] = string(content)
+		}
+		assert.Equal(t, "mimetype", archive.File[0].Name, testCase.description)
+		assert.Equal(t, zip.Store, archive.File[0].Method, testCase.description)
+		assert.Equal(t, odsMimeType, files["mimetype"], testCase.description)
+		for _, name := range []string{"META-INF/manifest.xml", "styles.xml", "content.xml"} {
+			decoder := xml.NewDecoder(bytes.NewReader([]byte(files[name])))
+			var xmlErr error
+			for xmlErr == nil {
+				_, xmlErr = decoder.Token()
+			}
+			assert.Equal(t, io.EOF, xmlErr, testCase.description+" "+name)
+		}
+		for _, fragment := range testCase.expect {
+			assert.Contains(t, files["content.xml"], fragment, testCase.description)
+		}
+	}
+}
@@ -0,0 +1,159 @@
+package xlsy
+
+import (
+	"github.com/xuri/excelize/v2"
+	"strconv"
+)
+
+// defaultColumnWidth represents excelize default column width
+const defaultColumnWidth = 9.140625
+
+// defaultFontSize represents excelize default font size in points
+const defaultFontSize = 11.0
+
+// builtInNumFormats represents excelize built-in date number formats assigned to unstyled time values
+var builtInNumFormats = map[int]string{
+	14: "mm-dd-yy",
+	22: "m/d/yy h:mm",
+}
+
+// renderedSheet represents worksheet transferred to excelize file, read back by ods encoder
+type renderedSheet struct {
+	*workSheet
+	stylizer *Stylizer
+	rows     [][]string
+	raw      [][]string
+	merged   map[Cursor]Cursor
+	covered  map[Cursor]bool
+	styles   map[int]*extStyle
+	width    int
+}
+
+func newRenderedSheet(aSheet *workSheet, stylizer *Stylizer) (*renderedSheet, error) {
+	ret := &renderedSheet{workSheet: aSheet, stylizer: stylizer, merged: map[Cursor]Cursor{}, covered: map[Cursor]bool{}, styles: map[int]*extStyle{}}
+	if aSheet.index == nil {
+		return ret, nil
+	}
+	var err error
+	if ret.rows, err = aSheet.dest.GetRows(aSheet.name); err != nil {
+		return nil, err
+	}
+	if ret.raw, err = aSheet.dest.GetRows(aSheet.name, excelize.Options{RawCellValue: true}); err != nil {
+		return nil, err
+	}
+	for _, row := range ret.rows {
+		if len(row) > ret.width {
+			ret.width = len(row)
+		}
+	}
+	mergeCells, err := aSheet.dest.GetMergeCells(aSheet.name)
+	if err != nil {
+		return nil, err
+	}
+	for _, cell := range mergeCells {
+		begin, err := parseCursor(cell.GetStartAxis())
+		if err != nil {
+			return nil, err
+		}
+		end, err := parseCursor(cell.GetEndAxis())
+		if err != nil {
+			return nil, err
+		}
+		if begin == end {
+			continue
+		}
+		ret.merged[begin] = end
+		for row := begin.row(); row <= end.row(); row++ {
+			for column := begin.column(); column <= end.column(); column++ {
+				if cur := newCursor(row, column); cur != begin {
+					ret.covered[cur] = true
+				}
+			}
+		}
+		for len(ret.rows) <= end.row() {
+			ret.rows = append(ret.rows, nil)
+		}
+		if end.column() >= ret.width {
+			ret.width = end.column() + 1
+		}
+	}
+	return ret, nil
+}
+
+// span returns number of columns and rows covered by cell
+func (s *renderedSheet) span(cur Cursor) (columns int, rows int) {
+	end, ok := s.merged[cur]
+	if !ok {
+		return 1, 1
+	}
+	return end.column() - cur.column() + 1, end.row() - cur.row() + 1
+}
+
+// cellStyle returns cell style ID and registered style, styles assigned by excelize are read from the file
+func (s *renderedSheet) cellStyle(cur Cursor) (int, *extStyle, error) {
+	styleID, err := s.dest.GetCellStyle(s.name, cur.String())
+	if err != nil || styleID == 0 {
+		return styleID, nil, err
+	}
+	if style, ok := s.styles[styleID]; ok {
+		return styleID, style, nil
+	}
+	style := s.stylizer.extStyle(styleID)
+	if style == nil {
+		fileStyle, err := s.dest.GetStyle(styleID)
+		if err != nil {
+			return 0, nil, err
+		}
+		style = &extStyle{ID: &styleID, Style: fileStyle}
+	}
+	s.styles[styleID] = style
+	return styleID, style, nil
+}
+
+// columnWidth returns column width and true if width is not default
+func (s *renderedSheet) columnWidth(column int) (float64, bool, error) {
+	width, err := s.dest.GetColWidth(s.name, numberToColumn(column))
+	if err != nil {
+		return 0, false, err
+	}
+	return width, width != defaultColumnWidth, nil
+}
+
+// cellText returns formatted cell text, xls number formats are rendered as in text encoders
+func (s *renderedSheet) cellText(cur Cursor, style *extStyle) string {
+	text := cellAt(s.rows, cur)
+	numFmt := style.numFormat()
+	if numFmt == "" {
+		return text
+	}
+	number, err := strconv.ParseFloat(cellAt(s.raw, cur), 64)
+	if err != nil {
+		return text
+	}
+	if isDateFormat(numFmt) {
+		ts, err := excelize.ExcelDateToTime(number, false)
+		if err != nil {
+			return text
+		}
+		return ts.Format(timeLayout(numFmt))
+	}
+	return formatNumber(number, numFmt)
+}
+
+// numFormat returns custom or built-in date number format or empty string
+func (e *extStyle) numFormat() string {
+	if e == nil || e.Style == nil {
+		return ""
+	}
+	if e.Style.CustomNumFmt != nil {
+		return *e.Style.CustomNumFmt
+	}
+	return builtInNumFormats[e.Style.NumFmt]
+}
+
+func cellAt(rows [][]string, cur Cursor) string {
+	if cur.row() >= len(rows) || cur.column() >= len(rows[cur.row()]) {
+		return ""
+	}
+	return rows[cur.row()][cur.column()]
+}
@@ -0,0 +1,264 @@
+package xlsy
+
+import (
+	"fmt"
+	"github.com/xuri/excelize/v2"
+	"reflect"
+	"strconv"
+	"strings"
+	"unsafe"
+)
+
+// RowStyler represents a record providing its row style definition, i.e. "background-color:#ffc7ce" for failed jobs
+type RowStyler interface {
+	RowStyle() string
+}
+
+var rowStylerType = reflect.TypeOf((*RowStyler)(nil)).Elem()
+
+// isRowStyler returns true if table record pointer implements RowStyler
+func (t *Table) isRowStyler() bool {
+	structType := ensureStruct(t.Type)
+	return structType != nil && reflect.PtrTo(structType).Implements(rowStylerType)
+}
+
+// rowStyleID returns registered style ID combining matching row.style with record RowStyler definition, zero for no row style
+func (t *Table) rowStyleID(index int, recordPtr unsafe.Pointer) (int, error) {
+	var definitions []string
+	if rowStyle := t.Tag.RowStyle; rowStyle != nil && rowStyle.Style != "" {
+		style := t.Stylizer.Style(rowStyle.Style)
+		if style != nil && style.matchNthChild(index+1) {
+			definitions = append(definitions, rowStyle.Style)
+		}
+	}
+	if t.rowStyler && recordPtr != nil {
+		record := reflect.NewAt(ensureStruct(t.Type), recordPtr).Interface().(RowStyler)
+		if definition := strings.TrimSpace(record.RowStyle()); definition != "" {
+			definitions = append(definitions, definition)
+		}
+	}
+	if len(definitions) == 0 {
+		return 0, nil
+	}
+	style := &Style{Definition: strings.Join(definitions, ";")}
+	if err := t.Stylizer.Register(style); err != nil {
+		return 0, err
+	}
+	if style.Cell.ID == nil {
+		return 0, nil
+	}
+	return *style.Cell.ID, nil
+}
+
+func (s *Style) updateNthChild(value string) error {
+	if _, _, err := parseNthChild(value); err != nil {
+		return err
+	}
+	s.NthChild = value
+	return nil
+}
+
+// matchNthChild returns true if 1-based row position matches style nth-child expression, even rows by default
+func (s *Style) matchNthChild(position int) bool {
+	expr := s.NthChild
+	if expr == "" {
+		expr = "even"
+	}
+	step, offset, _ := parseNthChild(expr)
+	if step == 0 {
+		return position == offset
+	}
+	return (position-offset)%step == 0 && (position-offset)/step >= 0
+}
+
+// parseNthChild parses css nth-child expression (even, odd, 3, 3n, 3n+1, n) into step and offset
+func parseNthChild(expr string) (int, int, error) {
+	expr = strings.ReplaceAll(strings.ToLower(strings.TrimSpace(expr)), " ", "")
+	switch expr {
+	case "even":
+		return 2, 0, nil
+	case "odd":
+		return 2, 1, nil
+	}
+	index := strings.Index(expr, "n")
+	if index == -1 {
+		offset, err := strconv.Atoi(expr)
+		if err != nil {
+			return 0, 0, fmt.Errorf("invalid nth-child: %v, %w", expr, err)
+		}
+		return 0, offset, nil
+	}
+	step, offset := 1, 0
+	var err error
+	switch coefficient := expr[:index]; coefficient {
+	case "":
+	case "-":
+		step = -1
+	default:
+		if step, err = strconv.Atoi(coefficient); err != nil {
+			return 0, 0, fmt.Errorf("invalid nth-child: %v, %w", expr, err)
+		}
+	}
+	if rest := expr[index+1:]; rest != "" {
+		if offset, err = strconv.Atoi(rest); err != nil {
+			return 0, 0, fmt.Errorf("invalid nth-child: %v, %w", expr, err)
+		}
+	}
+	return step, offset, nil
+}
+
+// recordStyleID returns record row style combined with parent row style, record cells spanning table header columns are styled upfront
+func (s *workSheet) recordStyleID(table *Table, row *Row, cursor Cursor, parentStyleID int) (int, error) {
+	rowStyleID := row.StyleID
+	if parentStyleID != 0 {
+		var err error
+		if rowStyleID, err = table.Stylizer.overlay(parentStyleID, row.StyleID); err != nil {
+			return 0, err
+		}
+	}
+	height := 1
+	for _, cell := range row.Values {
+		if cell.Rows() > height {
+			height = cell.Rows()
+		}
+	}
+	return rowStyleID, s.styleRow(table, rowStyleID, cursor, height)
+}
+
+// styleRow overlays row style over record cells spanning height rows and table header columns
+func (s *workSheet) styleRow(table *Table, rowStyleID int, cursor Cursor, height int) error {
+	from, to, ok := table.headerSpan()
+	if !ok {
+		return nil
+	}
+	useRow := table.UseRow(true)
+	begin := cursor.value(useRow)
+	for position := begin; position < begin+height; position++ {
+		for across := from; across <= to; across++ {
+			cell := newCursor(position, across)
+			if !useRow {
+				cell = newCursor(across, position)
+			}
+			styleID, err := s.GetCellStyle(cell.String())
+			if err != nil {
+				return err
+			}
+			if styleID, err = table.Stylizer.overlay(styleID, rowStyleID); err != nil {
+				return err
+			}
+			if err = s.SetCellStyle(cell.String(), cell.String(), styleID); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// headerSpan returns first and last header position across table records, nested tables with the same orientation are included
+func (t *Table) headerSpan() (int, int, bool) {
+	from, to, ok := 0, 0, false
+	if t.Header == nil {
+		return from, to, ok
+	}
+	useRow := t.UseRow(false)
+	for i, header := range t.Header.Values {
+		if header.snapshot == nil {
+			continue
+		}
+		first, last := header.snapshot.value(useRow), header.snapshot.value(useRow)
+		if colTable := t.columnByIndex(i).Table; colTable != nil && colTable.Invert() == t.Invert() && !colTable.IsStandalone() {
+			if nestedFirst, nestedLast, nested := colTable.headerSpan(); nested {
+				first, last = nestedFirst, nestedLast
+			}
+		}
+		if !ok || first < from {
+			from = first
+		}
+		if !ok || last > to {
+			to = last
+		}
+		ok = true
+	}
+	return from, to, ok
+}
+
+// overlay returns style ID of cell style with row style fill, font, alignment and borders applied
+func (s *Stylizer) overlay(styleID, rowStyleID int) (int, error) {
+	key := [2]int{styleID, rowStyleID}
+	if id, ok := s.overlays[key]; ok {
+		return id, nil
+	}
+	rowStyle := s.extStyle(rowStyleID)
+	if rowStyle == nil || rowStyle.Style == nil {
+		return styleID, nil
+	}
+	var style excelize.Style
+	derived := &extStyle{}
+	if registered := s.extStyle(styleID); registered != nil && registered.Style != nil {
+		*derived = *registered
+		style = *registered.Style
+	} else if styleID != 0 {
+		fileStyle, err := s.file.GetStyle(styleID)
+		if err != nil {
+			return 0, err
+		}
+		style = *fileStyle
+	}
+	overlayStyle(&style, rowStyle.Style)
+	id, err := s.file.NewStyle(&style)
+	if err != nil {
+		return 0, err
+	}
+	derived.ID = &id
+	derived.Style = &style
+	s.derive(id, derived)
+	if s.overlays == nil {
+		s.overlays = map[[2]int]int{}
+	}
+	s.overlays[key] = id
+	return id, nil
+}
+
+// overlayStyle applies style fill, font, alignment and borders over dest, dest number format takes precedence
+func overlayStyle(dest, style *excelize.Style) {
+	if len(style.Fill.Color) > 0 {
+		dest.Fill = style.Fill
+	}
+	if font := style.Font; font != nil {
+		merged := excelize.Font{}
+		if dest.Font != nil {
+			merged = *dest.Font
+		}
+		merged.Bold = merged.Bold || font.Bold
+		merged.Italic = merged.Italic || font.Italic
+		merged.Strike = merged.Strike || font.Strike
+		if font.Underline != "" {
+			merged.Underline = font.Underline
+		}
+		if font.Family != "" {
+			merged.Family = font.Family
+		}
+		if font.Size > 0 {
+			merged.Size = font.Size
+		}
+		if font.Color != "" {
+			merged.Color = font.Color
+		}
+		if font.VertAlign != "" {
+			merged.VertAlign = font.VertAlign
+		}
+		dest.Font = &merged
+	}
+	if alignment := style.Alignment; alignment != nil && dest.Alignment == nil {
+		merged := *alignment
+		dest.Alignment = &merged
+	}
+	borders := append([]excelize.Border{}, dest.Border...)
+	for _, border := range style.Border {
+		borders = setBorder(borders, border)
+	}
+	dest.Border = borders
+	if dest.NumFmt == 0 && dest.CustomNumFmt == nil {
+		dest.NumFmt, dest.CustomNumFmt = style.NumFmt, style.CustomNumFmt
+	}
+}
@@ -0,0 +1,141 @@
+package xlsy
+
+import (
+	"bytes"
+	"github.com/stretchr/testify/assert"
+	"github.com/xuri/excelize/v2"
+	"testing"
+)
+
+type rowStyleJob struct {
+	Name   string
+	Status string
+}
+
+func (j *rowStyleJob) RowStyle() string {
+	if j.Status == "failed" {
+		return "background-color:#ffc7ce;color:#9c0006"
+	}
+	return ""
+}
+
+type rowStyleBatch struct {
+	ID   int
+	Jobs []rowStyleJob
+}
+
+func TestStyle_matchNthChild(t *testing.T) {
+	var testCases = []struct {
+		description string
+		expr        string
+		expect      []int
+		hasError    bool
+	}{
+		{description: "default even", expect: []int{2, 4, 6}},
+		{description: "odd", expr: "odd", expect: []int{1, 3, 5}},
+		{description: "every third", expr: "3n", expect: []int{3, 6}},
+		{description: "every third with offset", expr: "3n+1", expect: []int{1, 4}},
+		{description: "first two", expr: "-n+2", expect: []int{1, 2}},
+		{description: "exact", expr: "5", expect: []int{5}},
+		{description: "invalid", expr: "xn", hasError: true},
+	}
+
+	for _, testCase := range testCases {
+		style := &Style{}
+		if testCase.expr != "" {
+			err := style.updateNthChild(testCase.expr)
+			if testCase.hasError {
+				assert.NotNil(t, err, testCase.description)
+				continue
+			}
+			assert.Nil(t, err, testCase.description)
+		}
+		var actual []int
+		for position := 1; position <= 6; position++ {
+			if style.matchNthChild(position) {
+				actual = append(actual, position)
+			}
+		}
+		assert.EqualValues(t, testCase.expect, actual, testCase.description)
+	}
+}
+
+func TestMarshaller_Marshal_rowStyle(t *testing.T) {
+	type Record struct {
+		ID     int     `xls:"name=Id"`
+		Amount float64 `xls:"style={format:usd}"`
+		Note   string
+	}
+	type Report struct {
+		Records []Record `xls:"row.style={nth-child:odd;font-style:italic}"`
+	}
+	records := []Record{{ID: 1, Amount: 1.5}, {ID: 2, Amount: 2, Note: "x"}, {ID: 3, Amount: 3}}
+
+	var testCases = []struct {
+		description string
+		options     []Option
+		source      interface{}
+		expect      map[string]string //cell fill color, number format and italic marker
+	}{
+		{
+			description: "zebra option",
+			options:     []Option{WithRowStyle("background-color:#f2f2f2")},
+			source:      records,
+			expect:      map[string]string{"A2": "", "A3": "F2F2F2", "B3": "F2F2F2 $#,##0.00", "C3": "F2F2F2", "A4": "", "B4": " $#,##0.00"},
+		},
+		{
+			description: "zebra streaming",
+			options:     []Option{WithRowStyle("background-color:#f2f2f2"), WithStreaming()},
+			source:      records,
+			expect:      map[string]string{"A2": "", "A3": "F2F2F2", "B3": "F2F2F2 $#,##0.00", "C3": "F2F2F2", "B4": " $#,##0.00"},
+		},
+		{
+			description: "row style tag",
+			source:      &Report{Records: records},
+			expect:      map[string]string{"A2": " italic", "B2": " $#,##0.00 italic", "A3": "", "C4": " italic"},
+		},
+		{
+			description: "row styler",
+			source:      []rowStyleJob{{Name: "a", Status: "ok"}, {Name: "b", Status: "failed"}},
+			expect:      map[string]string{"A2": "", "A3": "FFC7CE", "B3": "FFC7CE"},
+		},
+		{
+			description: "nested row styler over zebra",
+			options:     []Option{WithRowStyle("nth-child:1;background-color:#f2f2f2")},
+			source:      []rowStyleBatch{{ID: 1, Jobs: []rowStyleJob{{Name: "a", Status: "failed"}, {Name: "b", Status: "ok"}}}, {ID: 2}},
+			expect:      map[string]string{"A3": "F2F2F2", "A4": "F2F2F2", "B3": "FFC7CE", "C3": "FFC7CE", "B4": "F2F2F2", "A5": ""},
+		},
+	}
+
+	for _, testCase := range testCases {
+		data, err := NewMarshaller(testCase.options...).Marshal(testCase.source)
+		if !assert.Nil(t, err, testCase.description) {
+			continue
+		}
+		file, err := excelize.OpenReader(bytes.NewReader(data))
+		if !assert.Nil(t, err, testCase.description) {
+			continue
+		}
+		sheet := file.GetSheetName(0)
+		for cell, expect := range testCase.expect {
+			styleID, err := file.GetCellStyle(sheet, cell)
+			assert.Nil(t, err, testCase.description)
+			style, err := file.GetStyle(styleID)
+			if !assert.Nil(t, err, testCase.description) {
+				continue
+			}
+			actual := ""
+			if style.Fill.Type == "pattern" && len(style.Fill.Color) > 0 {
+				actual = style.Fill.Color[0]
+			}
+			if style.CustomNumFmt != nil {
+				actual += " " + *style.CustomNumFmt
+			}
+			if style.Font != nil && style.Font.Italic {
+				actual += " italic"
+			}
+			assert.EqualValues(t, expect, actual, testCase.description+" "+cell)
+		}
+		_ = file.Close()
+	}
+}
@@ -0,0 +1,151 @@
+package xlsy
+
+import (
+	"database/sql"
+	"github.com/viant/xreflect"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+var (
+	interfaceType = reflect.TypeOf((*interface{})(nil)).Elem()
+	boolType      = reflect.TypeOf(true)
+)
+
+// rowsSource represents sql.Rows record source, each row is scanned into a dynamic struct with a field per column
+type rowsSource struct {
+	rows        *sql.Rows
+	columnTypes []*sql.ColumnType
+	recordType  reflect.Type
+	err         error
+}
+
+func newRowsSource(rows *sql.Rows, aSession *session) (*rowsSource, error) {
+	columnTypes, err := rows.ColumnTypes()
+	if err != nil {
+		return nil, err
+	}
+	fields := make([]reflect.StructField, 0, len(columnTypes))
+	for i, columnType := range columnTypes {
+		field := reflect.StructField{Name: "Column" + strconv.Itoa(i+1), Type: interfaceType}
+		if columnTag := aSession.columnTags[columnType.Name()]; columnTag != "" {
+			field.Tag = reflect.StructTag(TagName + ":" + strconv.Quote(columnTag))
+		}
+		fields = append(fields, field)
+	}
+	return &rowsSource{rows: rows, columnTypes: columnTypes, recordType: reflect.StructOf(fields)}, nil
+}
+
+// setColumnNames sets table column names to rows column names unless column tag defines a name,
+// column names are not passed with the tag as they may contain tag separators, i.e. coalesce(a, b)
+func (r *rowsSource) setColumnNames(table *Table) {
+	for _, column := range table.Columns {
+		if column.Tag.Name != "" {
+			continue
+		}
+		column.Name = r.columnTypes[column.Field.Index].Name()
+	}
+}
+
+// iterator returns func(yield func(*T) bool) iterator over rows, scan error is stored in err
+func (r *rowsSource) iterator() interface{} {
+	yieldType := reflect.FuncOf([]reflect.Type{reflect.PointerTo(r.recordType)}, []reflect.Type{boolType}, false)
+	iteratorType := reflect.FuncOf([]reflect.Type{yieldType}, nil, false)
+	return reflect.MakeFunc(iteratorType, func(args []reflect.Value) []reflect.Value {
+		yield := args[0]
+		values := make([]interface{}, len(r.columnTypes))
+		valuePtrs := make([]interface{}, len(values))
+		for i := range values {
+			valuePtrs[i] = &values[i]
+		}
+		for r.rows.Next() {
+			if r.err = r.rows.Scan(valuePtrs...); r.err != nil {
+				return nil
+			}
+			record := reflect.New(r.recordType)
+			for i, value := range values {
+				if value = columnValue(value, r.columnTypes[i]); value != nil {
+					record.Elem().Field(i).Set(reflect.ValueOf(value))
+				}
+			}
+			if !yield.Call([]reflect.Value{record})[0].Bool() {
+				return nil
+			}
+		}
+		r.err = r.rows.Err()
+		return nil
+	}).Interface()
+}
+
+// columnValue converts scanned value according to column type
+func columnValue(value interface{}, columnType *sql.ColumnType) interface{} {
+	var text string
+	switch actual := value.(type) {
+	case nil:
+		return nil
+	case []byte:
+		text = string(actual)
+	case string:
+		text = actual
+	default:
+		return value
+	}
+	switch columnKind(columnType) {
+	case reflect.Int64:
+		if i, err := strconv.ParseInt(text, 10, 64); err == nil {
+			return i
+		}
+	case reflect.Float64:
+		if f, err := strconv.ParseFloat(text, 64); err == nil {
+			return f
+		}
+	case reflect.Bool:
+		if b, err := strconv.ParseBool(text); err == nil {
+			return b
+		}
+	case reflect.Struct:
+		if ts, err := parseTime(text, nil, false); err == nil {
+			return ts
+		}
+	}
+	return text
+}
+
+// columnKind returns cell kind for column type: Int64, Float64, Bool, Struct for time or String
+func columnKind(columnType *sql.ColumnType) reflect.Kind {
+	if scanType := columnType.ScanType(); scanType != nil {
+		switch scanType {
+		case xreflect.TimeType, reflect.TypeOf(sql.NullTime{}):
+			return reflect.Struct
+		case reflect.TypeOf(sql.NullInt64{}), reflect.TypeOf(sql.NullInt32{}), reflect.TypeOf(sql.NullInt16{}), reflect.TypeOf(sql.NullByte{}):
+			return reflect.Int64
+		case reflect.TypeOf(sql.NullFloat64{}):
+			return reflect.Float64
+		case reflect.TypeOf(sql.NullBool{}):
+			return reflect.Bool
+		}
+		switch scanType.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+			reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			return reflect.Int64
+		case reflect.Float32, reflect.Float64:
+			return reflect.Float64
+		case reflect.Bool:
+			return reflect.Bool
+		}
+	}
+	databaseType := strings.ToUpper(columnType.DatabaseTypeName())
+	switch {
+	case strings.Contains(databaseType, "INT"):
+		return reflect.Int64
+	case strings.Contains(databaseType, "DECIMAL"), strings.Contains(databaseType, "NUMERIC"), strings.Contains(databaseType, "NUMBER"),
+		strings.Contains(databaseType, "FLOAT"), strings.Contains(databaseType, "DOUBLE"), strings.Contains(databaseType, "REAL"):
+		return reflect.Float64
+	case strings.Contains(databaseType, "BOOL"):
+		return reflect.Bool
+	case strings.Contains(databaseType, "DATE"), strings.Contains(databaseType, "TIME"):
+		return reflect.Struct
+	}
+	return reflect.String
+}
@@ -0,0 +1,143 @@
+package xlsy
+
+import (
+	"bytes"
+	"database/sql"
+	"database/sql/driver"
+	"github.com/stretchr/testify/assert"
+	"github.com/xuri/excelize/v2"
+	"io"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type (
+	testDriver struct{}
+	testConn   struct{}
+	testStmt   struct {
+		query string
+	}
+	testRows struct {
+		columns []string
+		index   int
+	}
+)
+
+var (
+	testColumns       = []string{"id", "amount", "name", "created", "note"}
+	testExprQuery     = "SELECT id, amount, name, created, coalesce(note, '') FROM report"
+	testExprColumns   = []string{"id", "amount", "name", "created", "coalesce(note, '')"}
+	testDatabaseTypes = []string{"INT", "DECIMAL", "VARCHAR", "TIMESTAMP", "VARCHAR"}
+	testCreated       = time.Date(2023, 8, 1, 10, 30, 0, 0, time.UTC)
+	testValues        = [][]driver.Value{
+		{int64(1), []byte("12.50"), "name 1", testCreated, nil},
+		{int64(2), []byte("3"), "name 2", testCreated.Add(time.Hour), "note 2"},
+	}
+)
+
+func init() {
+	sql.Register("xlsytest", &testDriver{})
+}
+
+func (d *testDriver) Open(name string) (driver.Conn, error)          { return &testConn{}, nil }
+func (c *testConn) Prepare(query string) (driver.Stmt, error)        { return &testStmt{query: query}, nil }
+func (c *testConn) Close() error                                     { return nil }
+func (c *testConn) Begin() (driver.Tx, error)                        { return nil, driver.ErrSkip }
+func (s *testStmt) Close() error                                     { return nil }
+func (s *testStmt) NumInput() int                                    { return 0 }
+func (s *testStmt) Exec(args []driver.Value) (driver.Result, error)  { return nil, driver.ErrSkip }
+func (r *testRows) Columns() []string                                { return r.columns }
+func (r *testRows) Close() error                                     { return nil }
+func (r *testRows) ColumnTypeDatabaseTypeName(index int) string      { return testDatabaseTypes[index] }
+func (r *testRows) ColumnTypeScanType(index int) reflect.Type        { return reflect.TypeOf([]byte{}) }
+func (r *testRows) ColumnTypeNullable(index int) (nullable, ok bool) { return true, true }
+
+func (s *testStmt) Query(args []driver.Value) (driver.Rows, error) {
+	if s.query == testExprQuery {
+		return &testRows{columns: testExprColumns}, nil
+	}
+	return &testRows{columns: testColumns}, nil
+}
+
+func (r *testRows) Next(dest []driver.Value) error {
+	if r.index >= len(testValues) {
+		return io.EOF
+	}
+	copy(dest, testValues[r.index])
+	r.index++
+	return nil
+}
+
+func TestMarshaller_Marshal_rows(t *testing.T) {
+	type Report struct {
+		ID      int       `xls:"name=id"`
+		Amount  float64   `xls:"name=Total"`
+		Name    string    `xls:"name=name"`
+		Created time.Time `xls:"name=created"`
+		Note    string    `xls:"name=note"`
+	}
+	expect := []*Report{
+		{ID: 1, Amount: 12.5, Name: "name 1", Created: testCreated},
+		{ID: 2, Amount: 3, Name: "name 2", Created: testCreated.Add(time.Hour), Note: "note 2"},
+	}
+	tags := map[string]string{"amount": "name=Total,style={format:usd;width:120px}"}
+	quotedTags := map[string]string{"amount": tags["amount"], "note": `name=note "internal"`}
+	withoutNote := []*Report{
+		{ID: 1, Amount: 12.5, Name: "name 1", Created: testCreated},
+		{ID: 2, Amount: 3, Name: "name 2", Created: testCreated.Add(time.Hour)},
+	}
+	header := []string{"id", "Total", "name", "created", "note"}
+
+	var testCases = []struct {
+		description string
+		query       string
+		options     []Option
+		expect      []*Report
+		header      []string
+	}{
+		{description: "rows", options: []Option{WithColumnTags(tags)}, expect: expect, header: header},
+		{description: "streamed rows", options: []Option{WithColumnTags(tags), WithStreaming()}, expect: expect, header: header},
+		{description: "quoted column tag", options: []Option{WithColumnTags(quotedTags)}, expect: withoutNote,
+			header: []string{"id", "Total", "name", "created", `note "internal"`}},
+		{description: "expression column name", query: testExprQuery, options: []Option{WithColumnTags(tags)}, expect: withoutNote,
+			header: []string{"id", "Total", "name", "created", "coalesce(note, '')"}},
+	}
+
+	db, err := sql.Open("xlsytest", "")
+	if !assert.Nil(t, err) {
+		return
+	}
+	defer db.Close()
+	for _, testCase := range testCases {
+		query := testCase.query
+		if query == "" {
+			query = "SELECT * FROM report"
+		}
+		rows, err := db.Query(query)
+		if !assert.Nil(t, err, testCase.description) {
+			continue
+		}
+		data, err := NewMarshaller(testCase.options...).Marshal(rows)
+		_ = rows.Close()
+		if !assert.Nil(t, err, testCase.description) {
+			continue
+		}
+		var actual []*Report
+		if err = NewUnmarshaller().Unmarshal(data, &actual); !assert.Nil(t, err, testCase.description) {
+			continue
+		}
+		assert.EqualValues(t, testCase.expect, actual, testCase.description)
+
+		file, err := excelize.OpenReader(bytes.NewReader(data))
+		if !assert.Nil(t, err, testCase.description) {
+			continue
+		}
+		sheetRows, _ := file.GetRows(defaultSheetName)
+		assert.EqualValues(t, testCase.header, sheetRows[0], testCase.description)
+		amount, _ := file.GetCellValue(defaultSheetName, "B2")
+		assert.Equal(t, "$12.50", amount, testCase.description)
+		width, _ := file.GetColWidth(defaultSheetName, "B")
+		assert.Equal(t, 20.0, width, testCase.description)
+	}
+}
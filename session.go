@@ -1,6 +1,9 @@
 package xlsy
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"sync"
 )
 
@@ -14,8 +17,21 @@ type session struct {
 	sheets   map[string]*workSheet
 	names    []string
 	mux      sync.Mutex
+
+	collectErrors bool
+	cellErrors    CellErrors
+	streaming     bool
+	ods           bool
+	columnTags    map[string]string
+	rows          *rowsSource //sql.Rows source, naming table columns after rows columns
+	ctx           context.Context
+	progress      Progress
+	tableNames    map[string]bool
 }
 
+// Progress represents a callback reporting number of rows written to a sheet
+type Progress func(sheet string, rows int)
+
 func (m *session) apply(options []Option) error {
 	for _, opt := range options {
 		if err := opt(m); err != nil {
@@ -51,7 +67,10 @@ func (m *session) getOrCreateSheet(name string, first bool) (*workSheet, error)
 		return ret, nil
 	}
 
-	ret = &workSheet{name: name, dest: m.stylizer.file}
+	if m.tableNames == nil {
+		m.tableNames = map[string]bool{}
+	}
+	ret = &workSheet{name: name, dest: m.stylizer.file, ctx: m.ctx, progress: m.progress, tableNames: m.tableNames}
 	m.sheets[name] = ret
 	if first {
 		m.names = append([]string{name}, m.names...)
@@ -74,14 +93,51 @@ func (m *session) ensureFirst(name string, first bool) {
 	}
 }
 
+func (m *session) isStreaming() bool {
+	if m.parent != nil {
+		return m.parent.isStreaming()
+	}
+	return m.streaming
+}
+
+// reportCellError returns cell error or nil if errors are collected
+func (m *session) reportCellError(err *CellError) error {
+	if m.parent != nil {
+		return m.parent.reportCellError(err)
+	}
+	if !m.collectErrors {
+		return err
+	}
+	m.mux.Lock()
+	defer m.mux.Unlock()
+	m.cellErrors = append(m.cellErrors, err)
+	return nil
+}
+
+// decodingError combines collected cell errors with err
+func (m *session) decodingError(err error) error {
+	if len(m.cellErrors) == 0 {
+		return err
+	}
+	if err == nil {
+		return m.cellErrors
+	}
+	return errors.Join(m.cellErrors, err)
+}
+
 func newSession(parent *session, stylizer *Stylizer, tag *Tag) *session {
-	return &session{
+	ret := &session{
 		parent:   parent,
 		stylizer: stylizer,
 		sheets:   map[string]*workSheet{},
 		mux:      sync.Mutex{},
 		tag:      tag,
+		ctx:      context.Background(),
 	}
+	if parent != nil {
+		ret.ctx = parent.ctx
+	}
+	return ret
 }
 
 // WithTag return tag session
@@ -101,19 +157,121 @@ func WithInverted() Option {
 	}
 }
 
+// WithAutoWidth return option fitting columns width to rendered header and cell text, explicit column width takes precedence
+func WithAutoWidth() Option {
+	t := true
+	return func(m *session) error {
+		m.tag.AutoWidth = &t
+		return nil
+	}
+}
+
+// WithFreeze return option freezing root tables header rows and leading key columns
+func WithFreeze(columns int) Option {
+	return func(m *session) error {
+		m.tag.Freeze = freezeHeader
+		m.tag.FreezeColumns = columns
+		return nil
+	}
+}
+
+// WithAutoFilter return option applying auto filter on root tables header and data range
+func WithAutoFilter() Option {
+	return func(m *session) error {
+		m.tag.AutoFilter = true
+		return nil
+	}
+}
+
+// WithExcelTable return option rendering flat root tables as excel tables with built-in style (i.e. TableStyleMedium2, default for empty style)
+func WithExcelTable(style string) Option {
+	return func(m *session) error {
+		if style == "" {
+			style = defaultTableStyle
+		}
+		m.tag.ExcelTable = style
+		return nil
+	}
+}
+
+// WithTableBorder return option drawing outer box and inner grid borders (i.e. "1px solid #ccc") around rendered tables
+func WithTableBorder(box, grid string) Option {
+	return func(m *session) error {
+		m.tag.Box = box
+		m.tag.Grid = grid
+		return nil
+	}
+}
+
+// WithRowStyle return option with root tables records style (i.e. "background-color:#f2f2f2" for even rows or "nth-child:odd;color:gray")
+func WithRowStyle(definition string) Option {
+	return func(m *session) error {
+		m.tag.RowStyle = &StyleTag{Destination: "row", Style: definition}
+		return nil
+	}
+}
+
 // WithNamedStyles accept name/style definition pairs
 func WithNamedStyles(pairs ...string) Option {
 	return func(m *session) error {
-		if m.stylizer.namedStyles == nil {
-			m.stylizer.namedStyles = make(map[string]string)
+		if len(pairs)%2 != 0 {
+			return fmt.Errorf("invalid named styles: expected name/definition pairs, but had %v values", len(pairs))
 		}
+		styles := make(map[string]string)
 		for i := 0; i < len(pairs); i += 2 {
-			m.stylizer.namedStyles[pairs[0]] = pairs[1]
+			styles[pairs[i]] = pairs[i+1]
+		}
+		m.stylizer.addNamedStyles(styles)
+		return nil
+	}
+}
+
+// WithStreaming return option writing flat (non-nested, non-inverted) tables rows with excelize StreamWriter
+// streamed table with worksheet auto filter, conditional formats, data validation, box or grid border or auto width returns an error
+func WithStreaming() Option {
+	return func(m *session) error {
+		m.streaming = true
+		return nil
+	}
+}
+
+// WithODS return option writing OpenDocument spreadsheet (.ods) instead of xlsx
+func WithODS() Option {
+	return func(m *session) error {
+		m.ods = true
+		return nil
+	}
+}
+
+// WithColumnTags return option with xls tag (i.e. "style={format:usd;width:120px}") for each sql.Rows column name
+func WithColumnTags(tags map[string]string) Option {
+	return func(m *session) error {
+		if m.columnTags == nil {
+			m.columnTags = make(map[string]string)
+		}
+		for column, tag := range tags {
+			m.columnTags[column] = tag
 		}
 		return nil
 	}
 }
 
+// WithProgress return option reporting number of data rows written per sheet
+func WithProgress(progress Progress) Option {
+	return func(m *session) error {
+		m.progress = progress
+		return nil
+	}
+}
+
+// WithCollectErrors return option collecting all cell decoding errors in a workbook as CellErrors instead of stopping at the first one
+func WithCollectErrors() Option {
+	return func(m *session) error {
+		m.collectErrors = true
+		return nil
+	}
+}
+
 func WithDefaultHeaderStyle(definition string) Option {
 	return func(m *session) error {
 		m.stylizer.defaultHeaderStyle = definition
@@ -1,15 +1,38 @@
 package xlsy
 
 import (
+	"context"
+	"fmt"
 	"github.com/xuri/excelize/v2"
 )
 
+// cellStore represents worksheet cells destination, excelize file by default
+type cellStore interface {
+	SetCellValue(sheet, cell string, value interface{}) error
+	SetCellStyle(sheet, hCell, vCell string, styleID int) error
+	GetCellStyle(sheet, cell string) (int, error)
+	MergeCell(sheet, hCell, vCell string) error
+	SetColWidth(sheet, startCol, endCol string, width float64) error
+}
+
+// rowsFlusher represents cells destination writing rows laid out before a top level table record
+type rowsFlusher interface {
+	flushRows(table *Table, rows int) error
+}
+
 type workSheet struct {
 	index *int
 	name  string
 
-	tables []*Table
-	dest   *excelize.File
+	tables     []*Table
+	dest       *excelize.File
+	cells      cellStore //cells destination other than dest worksheet, i.e. html table
+	ctx        context.Context
+	progress   Progress
+	rows       int
+	extent     Cursor          //bottom right cell written by the current table transfer
+	widths     map[int]float64 //auto width columns measured widths
+	tableNames map[string]bool //workbook excel table names
 }
 
 func (s *workSheet) addTable(table *Table) {
@@ -26,37 +49,94 @@ func (s *workSheet) SetActiveSheet() {
 	s.dest.SetActiveSheet(*s.index)
 }
 
+// cellStore returns cells destination, dest worksheet is created on first use
+func (s *workSheet) cellStore() (cellStore, error) {
+	if s.cells != nil {
+		return s.cells, nil
+	}
+	return s.dest, s.ensureWorksheet()
+}
+
 func (s *workSheet) SetCellValue(cell string, value interface{}) error {
-	if err := s.ensureWorksheet(); err != nil {
+	cells, err := s.cellStore()
+	if err != nil {
 		return err
 	}
-	return s.dest.SetCellValue(s.name, cell, value)
+	s.expand(cell)
+	return cells.SetCellValue(s.name, cell, value)
 }
 
 func (s *workSheet) SetCellStyle(hCell, vCell string, styleID int) error {
-	if err := s.ensureWorksheet(); err != nil {
+	cells, err := s.cellStore()
+	if err != nil {
 		return err
 	}
-	return s.dest.SetCellStyle(s.name, hCell, vCell, styleID)
+	s.expand(vCell)
+	return cells.SetCellStyle(s.name, hCell, vCell, styleID)
+}
+
+func (s *workSheet) GetCellStyle(cell string) (int, error) {
+	cells, err := s.cellStore()
+	if err != nil {
+		return 0, err
+	}
+	return cells.GetCellStyle(s.name, cell)
 }
 
 func (s *workSheet) MergeCells(hCell, vCell string) error {
-	if err := s.ensureWorksheet(); err != nil {
+	cells, err := s.cellStore()
+	if err != nil {
 		return err
 	}
-	return s.dest.MergeCell(s.name, hCell, vCell)
+	s.expand(vCell)
+	return cells.MergeCell(s.name, hCell, vCell)
+}
+
+// expand extends current table extent with the cell
+func (s *workSheet) expand(cell string) {
+	cur, err := parseCursor(cell)
+	if err != nil {
+		return
+	}
+	row, column := s.extent.row(), s.extent.column()
+	if cur.row() > row {
+		row = cur.row()
+	}
+	if cur.column() > column {
+		column = cur.column()
+	}
+	s.extent.set(row, column)
 }
 
 func (s *workSheet) SetColWidth(startCol, endCol string, width float64) error {
-	if err := s.ensureWorksheet(); err != nil {
+	cells, err := s.cellStore()
+	if err != nil {
 		return err
 	}
-	return s.dest.SetColWidth(s.name, startCol, endCol, width)
+	return cells.SetColWidth(s.name, startCol, endCol, width)
+}
+
+// rowWritten checks context cancellation and reports data rows written to the sheet
+func (s *workSheet) rowWritten() error {
+	s.rows++
+	if s.progress != nil {
+		s.progress(s.name, s.rows)
+	}
+	if s.ctx == nil {
+		return nil
+	}
+	return s.ctx.Err()
 }
 
 func (s *workSheet) transfer() error {
 	loc := Cursor(0)
 	for _, table := range s.tables {
+		if table.source != nil {
+			if err := s.streamTable(table, &loc); err != nil {
+				return err
+			}
+			continue
+		}
 		if err := s.transferTable(table, &loc); err != nil {
 			return err
 		}
@@ -65,28 +145,61 @@ func (s *workSheet) transfer() error {
 }
 
 func (s *workSheet) transferTable(table *Table, addr *Cursor) (err error) {
+	cursor, err := s.layoutTable(table, addr)
+	if err != nil {
+		return err
+	}
+	if err = s.freeze(table, *addr, cursor); err != nil {
+		return err
+	}
+	if err = s.applyConditions(table, cursor, s.extent); err != nil {
+		return err
+	}
+	if err = s.applyValidations(table, cursor); err != nil {
+		return err
+	}
+	if err = s.fitColumns(); err != nil {
+		return err
+	}
+	if begin, end, ok := table.tableRange(cursor, s.extent); ok {
+		if err = s.addExcelTable(table, begin, end); err != nil {
+			return err
+		}
+		if err = s.autoFilter(table, begin, end); err != nil {
+			return err
+		}
+	}
+	return s.drawTableBorder(table, *addr, s.extent)
+}
+
+// layoutTable writes table header and data cells at adjusted address, returns the first data cell
+func (s *workSheet) layoutTable(table *Table, addr *Cursor) (Cursor, error) {
 	table.Tag.adjustAddress(addr)
 
 	cursor := addr.clone()
+	s.extent = cursor
 	headerDim, err := s.transferHeader(table, cursor)
 	if err != nil {
-		return err
+		return 0, err
 	}
 	cursor.inc(headerDim.value(table.UseRow(true)), table.UseRow(true))
-
-	if _, err = s.transferData(table, cursor); err != nil {
-		return err
-	}
-
-	return nil
+	_, err = s.transferData(table, cursor, 0)
+	return cursor, err
 }
 
-func (s *workSheet) transferData(table *Table, cursor Cursor) (dim Cursor, err error) {
+// transferData writes table rows, parentStyleID is a row style inherited from a parent table record
+func (s *workSheet) transferData(table *Table, cursor Cursor, parentStyleID int) (dim Cursor, err error) {
 
 	for i := 0; i < len(table.Rows); i++ {
 		callAddr := cursor.clone()
 		row := table.Rows[i]
 		height := 1
+		rowStyleID := parentStyleID
+		if row.StyleID != 0 {
+			if rowStyleID, err = s.recordStyleID(table, row, cursor, parentStyleID); err != nil {
+				return 0, err
+			}
+		}
 
 		for j, _ := range row.Values {
 			if j >= len(table.Header.Values) {
@@ -120,7 +233,7 @@ func (s *workSheet) transferData(table *Table, cursor Cursor) (dim Cursor, err e
 					}
 				}
 				colTable.Rows = cell.rows
-				_, err = s.transferData(colTable, subTableAddr)
+				_, err = s.transferData(colTable, subTableAddr, rowStyleID)
 				if err != nil {
 					return 0, err
 				}
@@ -130,9 +243,16 @@ func (s *workSheet) transferData(table *Table, cursor Cursor) (dim Cursor, err e
 				if err = s.SetCellValue(cellAddr, cell.value); err != nil {
 					return 0, err
 				}
+				s.measure(table.Stylizer, column, callAddr, column.text(table.Stylizer, cell.value), false)
 			}
 			if cell.styleID != nil {
-				if err = s.SetCellStyle(cellAddr, cellAddr, *cell.styleID); err != nil {
+				styleID := *cell.styleID
+				if rowStyleID != 0 {
+					if styleID, err = table.Stylizer.overlay(styleID, rowStyleID); err != nil {
+						return 0, err
+					}
+				}
+				if err = s.SetCellStyle(cellAddr, cellAddr, styleID); err != nil {
 					return 0, err
 				}
 			}
@@ -142,6 +262,16 @@ func (s *workSheet) transferData(table *Table, cursor Cursor) (dim Cursor, err e
 			}
 		}
 		cursor.inc(height, table.UseRow(true))
+		if table.IsStandalone() {
+			if err = s.rowWritten(); err != nil {
+				return 0, err
+			}
+		}
+		if flusher, ok := s.cells.(rowsFlusher); ok && table.Parent == nil {
+			if err = flusher.flushRows(table, cursor.row()); err != nil {
+				return 0, err
+			}
+		}
 	}
 	return dim, nil
 }
@@ -159,11 +289,8 @@ func (s *workSheet) transferHeader(table *Table, cur Cursor) (dim Cursor, err er
 	}
 	for i, header := range table.Header.Values {
 		span := 1
-		if len(table.Rows) == 0 || i >= len(table.Rows[0].Values) {
-			continue
-		}
 		column := table.columnByIndex(i)
-		if column.Tag.Omitempty && !table.Rows[0].Values[i].HasValue() {
+		if column.Tag.Omitempty && !table.firstRowHasValue(i) {
 			continue
 		}
 		//TODO check omit empty with vertical to skip it
@@ -173,6 +300,7 @@ func (s *workSheet) transferHeader(table *Table, cur Cursor) (dim Cursor, err er
 			if err = s.SetCellValue(cellAddr, header.value); err != nil {
 				return 0, err
 			}
+			s.measure(table.Stylizer, column, cur, fmt.Sprint(header.value), true)
 			if header.styleID != nil {
 				if err = s.SetCellStyle(cellAddr, cellAddr, *header.styleID); err != nil {
 					return 0, err
@@ -221,7 +349,7 @@ func (s *workSheet) transferHeader(table *Table, cur Cursor) (dim Cursor, err er
 }
 
 func (s *workSheet) mergeHeaders(table *Table, height int) (err error) {
-	if height < 1 { //nothing to merge
+	if height <= 1 { //nothing to merge, excel tables do not allow merged cells
 		return nil
 	}
 	// merge height
@@ -0,0 +1,163 @@
+package xlsy
+
+import (
+	"fmt"
+	"github.com/xuri/excelize/v2"
+	"unsafe"
+)
+
+// checkStreaming returns an error for table features excelize StreamWriter cannot write
+func (t *Table) checkStreaming() error {
+	if t.Tag.Box != "" || t.Tag.Grid != "" {
+		return fmt.Errorf("unsupported box or grid border for streamed table: %v", t.SheetName())
+	}
+	if t.AutoFilter && !t.isExcelTable() {
+		return fmt.Errorf("unsupported auto filter for streamed table: %v", t.SheetName())
+	}
+	for _, column := range t.Columns {
+		if column.Tag.Ignore || column.Tag.Blank {
+			continue
+		}
+		formats, err := column.conditionalFormats(t.Stylizer)
+		if err != nil {
+			return err
+		}
+		if len(formats) > 0 {
+			return fmt.Errorf("unsupported conditional format for streamed table: %v, column: %v", t.SheetName(), column.Name)
+		}
+		if column.validation != nil {
+			return fmt.Errorf("unsupported data validation for streamed table: %v, column: %v", t.SheetName(), column.Name)
+		}
+	}
+	return t.checkAutoWidth()
+}
+
+// streamTable writes flat table header and source records with excelize StreamWriter
+func (s *workSheet) streamTable(table *Table, addr *Cursor) error {
+	if err := s.ensureWorksheet(); err != nil {
+		return err
+	}
+	writer, err := s.dest.NewStreamWriter(s.name)
+	if err != nil {
+		return err
+	}
+	table.Tag.adjustAddress(addr)
+	cursor := addr.clone()
+	data := cursor.clone()
+	data.incRow(1)
+	if panes := table.freezePanes(*addr, data); panes != nil {
+		if err = writer.SetPanes(panes); err != nil {
+			return err
+		}
+	}
+	header := cursor.clone()
+	cursor.incRow(1)
+	columns, err := s.streamData(writer, table, header, &cursor)
+	if err != nil {
+		return err
+	}
+	if table.Header != nil {
+		end := newCursor(cursor.row()-1, addr.column()+columns-1)
+		if excelTable := s.excelTable(table, *addr, end); excelTable != nil {
+			if err = writer.AddTable(excelTable); err != nil {
+				return err
+			}
+		}
+	}
+	return writer.Flush()
+}
+
+// streamHeader writes table header, omitempty columns without the first record value are skipped as with transferHeader,
+// it returns number of header columns
+func (s *workSheet) streamHeader(writer *excelize.StreamWriter, table *Table, cursor Cursor, recordPtr unsafe.Pointer) (int, error) {
+	if table.Header == nil {
+		return 0, nil
+	}
+	cells := make([]interface{}, 0, len(table.Header.Values))
+	for i, header := range table.Header.Values {
+		if table.columnByIndex(i).isOmitted(recordPtr) {
+			continue
+		}
+		column := cursor.column() + len(cells) + 1
+		cells = append(cells, nil)
+		if header.width > 0 {
+			if err := writer.SetColWidth(column, column, header.width); err != nil {
+				return 0, err
+			}
+		}
+		if header.value == nil {
+			continue
+		}
+		cell := excelize.Cell{Value: header.value}
+		if header.styleID != nil {
+			cell.StyleID = *header.styleID
+		}
+		cells[len(cells)-1] = cell
+	}
+	return len(cells), writer.SetRow(cursor.String(), cells)
+}
+
+// streamData writes the header ahead of the first record and table records, it returns number of header columns
+func (s *workSheet) streamData(writer *excelize.StreamWriter, table *Table, header Cursor, cursor *Cursor) (int, error) {
+	columns, headerWritten := 0, false
+	err := table.forEachRecord(table.source, func(index int, recordPtr unsafe.Pointer) (err error) {
+		if !headerWritten {
+			headerWritten = true
+			if columns, err = s.streamHeader(writer, table, header, recordPtr); err != nil {
+				return err
+			}
+		}
+		cells, err := s.streamRecord(table, index, recordPtr)
+		if err != nil {
+			return err
+		}
+		if err = writer.SetRow(cursor.String(), cells); err != nil {
+			return err
+		}
+		cursor.incRow(1)
+		return s.rowWritten()
+	})
+	if err != nil || headerWritten {
+		return columns, err
+	}
+	return s.streamHeader(writer, table, header, nil)
+}
+
+func (s *workSheet) streamRecord(table *Table, index int, recordPtr unsafe.Pointer) ([]interface{}, error) {
+	rowStyleID, err := table.rowStyleID(index, recordPtr)
+	if err != nil {
+		return nil, err
+	}
+	var cells []interface{}
+	if table.Header != nil {
+		cells = make([]interface{}, 0, len(table.Header.Values))
+	}
+	for _, column := range table.Columns {
+		if column.Tag.Ignore {
+			continue
+		}
+		if column.Tag.Blank {
+			if rowStyleID != 0 {
+				cells = append(cells, excelize.Cell{StyleID: rowStyleID})
+				continue
+			}
+			cells = append(cells, nil)
+			continue
+		}
+		value, ok := column.recordValue(recordPtr)
+		if !ok && column.Tag.Omitempty {
+			continue
+		}
+		cell := excelize.Cell{Value: value}
+		if styleID := column.CellStyleID(table.Stylizer); styleID != nil {
+			cell.StyleID = *styleID
+		}
+		if rowStyleID != 0 {
+			if cell.StyleID, err = table.Stylizer.overlay(cell.StyleID, rowStyleID); err != nil {
+				return nil, err
+			}
+		}
+		cells = append(cells, cell)
+	}
+	return cells, nil
+}
@@ -0,0 +1,91 @@
+package xlsy
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"github.com/xuri/excelize/v2"
+	"testing"
+	"time"
+)
+
+func TestWorkSheet_streamTable(t *testing.T) {
+	now := time.Now().UTC().Truncate(time.Second)
+	type Record struct {
+		ID      int    `xls:"name=Id"`
+		Name    string `xls:"style={width:200px;color:blue}"`
+		Note    string `xls:"-"`
+		Amount  float64
+		Started time.Time `xls:"style={format:date}"`
+	}
+	var records []*Record
+	for i := 0; i < 1000; i++ {
+		records = append(records, &Record{ID: i, Name: fmt.Sprintf("name %v", i), Amount: float64(i) * 1.5, Started: now})
+	}
+	rank := 1
+	type Optional struct {
+		ID   int    `xls:"name=Id"`
+		Rank *int   `xls:",omitempty"`
+		Name string `xls:"style={width:200px;color:blue}"`
+	}
+
+	var testCases = []struct {
+		description string
+		get         func() interface{}
+		options     []Option
+	}{
+		{
+			description: "slice",
+			get:         func() interface{} { return records },
+		},
+		{
+			description: "holder",
+			get: func() interface{} {
+				return &struct {
+					Records []*Record
+				}{Records: records}
+			},
+			options: []Option{WithTag(&Tag{WorkSheet: "Records"})},
+		},
+		{
+			description: "omitempty",
+			get: func() interface{} {
+				return []*Optional{{ID: 1, Name: "a"}, {ID: 2, Rank: &rank, Name: "b"}}
+			},
+		},
+		{
+			description: "omitempty without records",
+			get:         func() interface{} { return []*Optional{} },
+		},
+	}
+
+	for _, testCase := range testCases {
+		expect, err := NewMarshaller(testCase.options...).Marshal(testCase.get())
+		if !assert.Nil(t, err, testCase.description) {
+			continue
+		}
+		actual, err := NewMarshaller(append(testCase.options, WithStreaming())...).Marshal(testCase.get())
+		if !assert.Nil(t, err, testCase.description) {
+			continue
+		}
+		expectFile, err := excelize.OpenReader(bytes.NewReader(expect))
+		assert.Nil(t, err, testCase.description)
+		actualFile, err := excelize.OpenReader(bytes.NewReader(actual))
+		assert.Nil(t, err, testCase.description)
+		sheets := expectFile.GetSheetList()
+		assert.EqualValues(t, sheets, actualFile.GetSheetList(), testCase.description)
+		for _, sheet := range sheets {
+			expectRows, _ := expectFile.GetRows(sheet)
+			actualRows, _ := actualFile.GetRows(sheet)
+			assert.EqualValues(t, expectRows, actualRows, testCase.description)
+			for _, cell := range []string{"A1", "B1", "B2", "D1000"} {
+				expectStyle, _ := expectFile.GetCellStyle(sheet, cell)
+				actualStyle, _ := actualFile.GetCellStyle(sheet, cell)
+				assert.Equal(t, expectStyle, actualStyle, testCase.description+" "+cell)
+			}
+			expectWidth, _ := expectFile.GetColWidth(sheet, "B")
+			actualWidth, _ := actualFile.GetColWidth(sheet, "B")
+			assert.Equal(t, expectWidth, actualWidth, testCase.description)
+		}
+	}
+}
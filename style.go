@@ -32,6 +32,12 @@ type (
 		Header      *extStyle
 		Cell        *extStyle
 		Column      *extStyle
+		When        string   //conditional format criteria
+		Scale       []string //column range color scale colors
+		DataBar     string   //column range data bar color
+		IconSet     string   //column range icon set
+		NthChild    string   //row style nth-child expression, even by default
+		AutoWidth   bool     //width:auto fits column width to its content
 	}
 )
 
@@ -66,7 +72,7 @@ func (e *extStyle) ensureFont() {
 
 func (e *extStyle) ensureAlignment() {
 	e.ensureStyle()
-	if e.Style.Alignment == nil {
+	if e.Style.Alignment != nil {
 		return
 	}
 	e.Style.Alignment = &excelize.Alignment{}
@@ -74,7 +80,7 @@ func (e *extStyle) ensureAlignment() {
 
 func (e *extStyle) ensureProtection() {
 	e.ensureStyle()
-	if e.Style.Protection == nil {
+	if e.Style.Protection != nil {
 		return
 	}
 	e.Style.Protection = &excelize.Protection{}
@@ -99,6 +105,16 @@ func (s *Style) update(key, value string) (err error) {
 		s.updateFontStyle(value, dest)
 	case "font-family":
 		s.updateFontFamily(value, dest)
+	case "font-size":
+		return s.updateFontSize(value, dest)
+	case "text-decoration":
+		return s.updateTextDecoration(value, dest)
+	case "text-rotation":
+		return s.updateTextRotation(value, dest)
+	case "writing-mode":
+		return s.updateWritingMode(value, dest)
+	case "shrink-to-fit":
+		return s.updateShrinkToFit(value, dest)
 	case "color":
 		return s.updateColor(value, dest)
 	case "vertical-align":
@@ -117,6 +133,20 @@ func (s *Style) update(key, value string) (err error) {
 		return s.updateHeight(value, dest)
 	case "format":
 		s.updateFormat(value, dest)
+	case "when":
+		s.When = strings.Trim(value, `"'`)
+	case "scale", "color-scale":
+		return s.updateScale(value)
+	case "data-bar":
+		return s.updateDataBar(value)
+	case "icon-set":
+		return s.updateIconSet(value)
+	case "nth-child":
+		return s.updateNthChild(value)
+	case "border":
+		return s.updateBorder(value, dest, borderSides...)
+	case "border-top", "border-right", "border-bottom", "border-left":
+		return s.updateBorder(value, dest, strings.ToLower(key)[len("border-"):])
 	}
 	return nil
 }
@@ -170,6 +200,10 @@ func (s *Style) updateHeight(value string, style *extStyle) (err error) {
 }
 
 func (s *Style) updateWidth(value string) (err error) {
+	if strings.EqualFold(value, "auto") {
+		s.AutoWidth = true
+		return nil
+	}
 	if s.Cell.Width, err = parseLength(value); err != nil {
 		return fmt.Errorf("invalid width: %w, %s", err, value)
 	}
@@ -187,6 +221,20 @@ func (s *Style) updateMaxWidth(value string) (err error) {
 
 func (s *Style) updateVerticalAlign(value string, style *extStyle) error {
 	value = strings.ToLower(value)
+	switch value {
+	case "superscript", "super":
+		style.ensureFont()
+		style.Font.VertAlign = "superscript"
+		return nil
+	case "subscript", "sub":
+		style.ensureFont()
+		style.Font.VertAlign = "subscript"
+		return nil
+	case "baseline":
+		style.ensureFont()
+		style.Font.VertAlign = "baseline"
+		return nil
+	}
 	style.ensureAlignment()
 	if err := s.validateVerticalAlign(value); err != nil {
 		return err
@@ -222,7 +270,8 @@ func (s *Style) validateTextAlign(value string) error {
 
 func (s *Style) updateBackgroundColor(value string, style *extStyle) (err error) {
 	style.ensureStyle()
-	style.Fill.Type = "solid"
+	style.Fill.Type = "pattern"
+	style.Fill.Pattern = 1 //solid
 	style.Fill.Color, err = ensureColors(value)
 	return err
 }
@@ -300,6 +349,93 @@ func (s *Style) updateFontFamily(value string, style *extStyle) {
 	style.Font.Family = value
 }
 
+// updateFontSize sets font size in points, px size is converted to points
+func (s *Style) updateFontSize(value string, style *extStyle) error {
+	size, err := parseLength(value)
+	if err != nil {
+		return fmt.Errorf("invalid font-size: %w, %s", err, value)
+	}
+	if size.Size <= 0 {
+		return fmt.Errorf("invalid font-size: %s", value)
+	}
+	style.ensureFont()
+	style.Font.Size = size.Size
+	if strings.HasSuffix(strings.ToLower(value), "px") {
+		style.Font.Size = size.Size * 0.75
+	}
+	return nil
+}
+
+func (s *Style) updateTextDecoration(value string, style *extStyle) error {
+	style.ensureFont()
+	for _, item := range strings.Fields(value) {
+		switch strings.ToLower(item) {
+		case "underline":
+			style.Font.Underline = "single"
+		case "double-underline":
+			style.Font.Underline = "double"
+		case "line-through", "strike":
+			style.Font.Strike = true
+		case "none":
+			style.Font.Underline = ""
+			style.Font.Strike = false
+		default:
+			return fmt.Errorf("unsupported text-decoration: %s", value)
+		}
+	}
+	return nil
+}
+
+// updateTextRotation sets counterclockwise text rotation in degrees (-90..90), clockwise rotation is stored as 90 + degrees
+func (s *Style) updateTextRotation(value string, style *extStyle) error {
+	value = strings.ToLower(strings.TrimSpace(value))
+	style.ensureAlignment()
+	if value == "vertical" {
+		style.Alignment.TextRotation = 255
+		return nil
+	}
+	degrees, err := strconv.Atoi(strings.TrimSuffix(value, "deg"))
+	if err != nil {
+		return fmt.Errorf("invalid text-rotation: %w, %v", err, value)
+	}
+	switch {
+	case degrees < -90 || degrees > 90:
+		return fmt.Errorf("unsupported text-rotation: %s, expected -90..90deg or vertical", value)
+	case degrees < 0:
+		degrees = 90 - degrees
+	}
+	style.Alignment.TextRotation = degrees
+	return nil
+}
+
+func (s *Style) updateWritingMode(value string, style *extStyle) error {
+	style.ensureAlignment()
+	switch strings.ToLower(value) {
+	case "horizontal-tb":
+		style.Alignment.TextRotation = 0
+	case "vertical-lr", "vertical-rl":
+		style.Alignment.TextRotation = 255
+	case "sideways-lr":
+		style.Alignment.TextRotation = 90
+	case "sideways-rl":
+		style.Alignment.TextRotation = 180
+	default:
+		return fmt.Errorf("unsupported writing-mode: %s", value)
+	}
+	return nil
+}
+
+func (s *Style) updateShrinkToFit(value string, style *extStyle) (err error) {
+	style.ensureAlignment()
+	style.Alignment.ShrinkToFit = true
+	if value != "" {
+		if style.Alignment.ShrinkToFit, err = strconv.ParseBool(value); err != nil {
+			return fmt.Errorf("invalid shrink-to-fit: %w, %v", err, value)
+		}
+	}
+	return nil
+}
+
 func parseLength(length string) (*Length, error) {
 	cursor := parsly.NewCursor("", []byte(length), 0)
 	match := cursor.MatchAny(numberMatcher)
@@ -362,7 +498,7 @@ func matchStylePair(cursor *parsly.Cursor, match *parsly.TokenMatch) (string, st
 	case singleQuotedToken:
 		value = match.Text(cursor)
 		value = value[1 : len(value)-1]
-		match = cursor.MatchAny(comaTerminatorMatcher)
+		match = cursor.MatchAny(semicolonTerminatorMatcher)
 	case semicolonTerminatorToken:
 		value = match.Text(cursor)
 		value = value[:len(value)-1] //exclude ,
@@ -1,7 +1,9 @@
 package xlsy
 
 import (
+	"bytes"
 	"github.com/stretchr/testify/assert"
+	"github.com/xuri/excelize/v2"
 	"testing"
 )
 
@@ -21,3 +23,118 @@ func TestStyle_Init(t *testing.T) {
 		assert.Nil(t, err, testCase.description)
 	}
 }
+
+func TestStyle_Init_text(t *testing.T) {
+	var testCases = []struct {
+		description string
+		definition  string
+		expectFont  *excelize.Font
+		expectAlign *excelize.Alignment
+		hasError    bool
+	}{
+		{
+			description: "font size in points",
+			definition:  "font-size:14pt;text-decoration:underline line-through",
+			expectFont:  &excelize.Font{Size: 14, Underline: "single", Strike: true},
+		},
+		{
+			description: "font size in pixels",
+			definition:  "font-size:16px;text-decoration:double-underline;vertical-align:superscript",
+			expectFont:  &excelize.Font{Size: 12, Underline: "double", VertAlign: "superscript"},
+		},
+		{
+			description: "subscript",
+			definition:  "vertical-align:sub",
+			expectFont:  &excelize.Font{VertAlign: "subscript"},
+		},
+		{
+			description: "counterclockwise rotation",
+			definition:  "text-rotation:45deg;shrink-to-fit:true",
+			expectAlign: &excelize.Alignment{TextRotation: 45, ShrinkToFit: true},
+		},
+		{
+			description: "clockwise rotation",
+			definition:  "text-rotation:-30",
+			expectAlign: &excelize.Alignment{TextRotation: 120},
+		},
+		{
+			description: "vertical writing mode",
+			definition:  "writing-mode:vertical-rl;vertical-align:center",
+			expectAlign: &excelize.Alignment{TextRotation: 255, Vertical: "center"},
+		},
+		{
+			description: "quoted value followed by declarations",
+			definition:  "format:'#,##0.00';text-align:center;color:red",
+			expectFont:  &excelize.Font{Color: "#ff0000"},
+			expectAlign: &excelize.Alignment{Horizontal: "center"},
+		},
+		{
+			description: "invalid rotation",
+			definition:  "text-rotation:120deg",
+			hasError:    true,
+		},
+		{
+			description: "invalid decoration",
+			definition:  "text-decoration:overline",
+			hasError:    true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		style := &Style{Definition: testCase.definition}
+		err := style.Init()
+		if testCase.hasError {
+			assert.NotNil(t, err, testCase.description)
+			continue
+		}
+		if !assert.Nil(t, err, testCase.description) {
+			continue
+		}
+		assert.EqualValues(t, testCase.expectFont, style.Cell.Font, testCase.description)
+		assert.EqualValues(t, testCase.expectAlign, style.Cell.Alignment, testCase.description)
+	}
+}
+
+func TestExtStyle_ensure(t *testing.T) {
+	style := &extStyle{}
+	style.ensureAlignment()
+	if assert.NotNil(t, style.Alignment) {
+		style.Alignment.Horizontal = "center"
+		style.ensureAlignment()
+		assert.EqualValues(t, "center", style.Alignment.Horizontal)
+	}
+	style.ensureProtection()
+	if assert.NotNil(t, style.Protection) {
+		style.Protection.Locked = true
+		style.ensureProtection()
+		assert.True(t, style.Protection.Locked)
+	}
+}
+
+func TestMarshaller_Marshal_backgroundColor(t *testing.T) {
+	type Record struct {
+		ID     int     `xls:"name=Id"`
+		Amount float64 `xls:"style={background-color:yellow}"`
+	}
+	style := &Style{Definition: "background-color:yellow"}
+	if assert.Nil(t, style.Init()) {
+		assert.EqualValues(t, excelize.Fill{Type: "pattern", Pattern: 1, Color: []string{"#ffff00"}}, style.Cell.Fill)
+	}
+	data, err := NewMarshaller().Marshal([]*Record{{ID: 1, Amount: 2}})
+	if !assert.Nil(t, err) {
+		return
+	}
+	file, err := excelize.OpenReader(bytes.NewReader(data))
+	if !assert.Nil(t, err) {
+		return
+	}
+	defer file.Close()
+	styleID, err := file.GetCellStyle(defaultSheetName, "B2")
+	if !assert.Nil(t, err) {
+		return
+	}
+	actual, err := file.GetStyle(styleID)
+	if assert.Nil(t, err) {
+		assert.EqualValues(t, excelize.Fill{Type: "pattern", Pattern: 1, Color: []string{"FFFF00"}}, actual.Fill)
+	}
+}
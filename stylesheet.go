@@ -0,0 +1,206 @@
+package xlsy
+
+import (
+	"fmt"
+	"io/fs"
+	"strings"
+)
+
+// styleDestinations represents destinations a class rule can target with ::destination pseudo element
+var styleDestinations = []string{"cell", "header", "column", "row", "cond"}
+
+// parseStylesheet parses css like stylesheet into named style definitions keyed by class name,
+// destination rules (i.e. ".money::header { text-align: right }") are keyed by class::destination
+func parseStylesheet(css string) (map[string]string, error) {
+	css = stripComments(css)
+	ret := map[string]string{}
+	for strings.TrimSpace(css) != "" {
+		begin := strings.Index(css, "{")
+		if begin == -1 {
+			return nil, fmt.Errorf("invalid stylesheet: missing '{' after: %v", strings.TrimSpace(css))
+		}
+		end := strings.Index(css[begin:], "}")
+		if end == -1 {
+			return nil, fmt.Errorf("invalid stylesheet: missing '}' after: %v", strings.TrimSpace(css[:begin]))
+		}
+		selectors, body := css[:begin], css[begin+1:begin+end]
+		css = css[begin+end+1:]
+		definition := strings.Join(splitDeclarations(body), ";")
+		for _, selector := range strings.Split(selectors, ",") {
+			name, err := parseSelector(selector)
+			if err != nil {
+				return nil, err
+			}
+			if prev := ret[name]; prev != "" && definition != "" {
+				ret[name] = prev + ";" + definition
+				continue
+			}
+			ret[name] = definition
+		}
+	}
+	return ret, nil
+}
+
+// parseSelector returns class name with optional ::destination suffix
+func parseSelector(selector string) (string, error) {
+	selector = strings.TrimSpace(selector)
+	if !strings.HasPrefix(selector, ".") || len(selector) == 1 {
+		return "", fmt.Errorf("unsupported stylesheet selector: '%v', expected .class or .class::destination", selector)
+	}
+	name := selector[1:]
+	index := strings.Index(name, "::")
+	if index == -1 {
+		return name, nil
+	}
+	destination := strings.ToLower(name[index+2:])
+	for _, candidate := range styleDestinations {
+		if candidate == destination {
+			return name[:index] + "::" + destination, nil
+		}
+	}
+	return "", fmt.Errorf("unsupported stylesheet selector destination: '%v'", selector)
+}
+
+// splitDeclarations splits rule body into trimmed key:value declarations, semicolons within quotes are preserved
+func splitDeclarations(body string) []string {
+	var ret []string
+	quote := rune(0)
+	begin := 0
+	appendDeclaration := func(declaration string) {
+		index := strings.Index(declaration, ":")
+		if index == -1 {
+			return
+		}
+		ret = append(ret, strings.TrimSpace(declaration[:index])+":"+strings.TrimSpace(declaration[index+1:]))
+	}
+	for i, r := range body {
+		switch {
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			}
+		case r == '\'' || r == '"':
+			quote = r
+		case r == ';':
+			appendDeclaration(body[begin:i])
+			begin = i + 1
+		}
+	}
+	appendDeclaration(body[begin:])
+	return ret
+}
+
+func stripComments(css string) string {
+	for {
+		begin := strings.Index(css, "/*")
+		if begin == -1 {
+			return css
+		}
+		end := strings.Index(css[begin+2:], "*/")
+		if end == -1 {
+			return css[:begin]
+		}
+		css = css[:begin] + css[begin+2+end+2:]
+	}
+}
+
+// namedStyle returns class definition with composed classes expanded ahead of class declarations
+func (s *Stylizer) namedStyle(name string, visited map[string]bool) (string, bool, error) {
+	definition, ok := s.namedStyles[name]
+	if !ok {
+		return "", false, nil
+	}
+	if visited[name] {
+		return "", false, fmt.Errorf("circular style composition: %v", name)
+	}
+	visited[name] = true
+	defer delete(visited, name)
+	var composed, declarations []string
+	for _, declaration := range splitDeclarations(definition) {
+		if !strings.HasPrefix(strings.ToLower(declaration), "composes:") {
+			declarations = append(declarations, declaration)
+			continue
+		}
+		for _, ref := range strings.Fields(declaration[len("composes:"):]) {
+			refDefinition, ok, err := s.namedStyle(ref, visited)
+			if err != nil {
+				return "", false, err
+			}
+			if !ok {
+				return "", false, fmt.Errorf("failed to lookup composed style: %s", ref)
+			}
+			if refDefinition != "" {
+				composed = append(composed, refDefinition)
+			}
+		}
+	}
+	return strings.Join(append(composed, declarations...), ";"), true, nil
+}
+
+// destinationStyle returns class destination rules (i.e. .money::header) with composed classes destination rules expanded first
+func (s *Stylizer) destinationStyle(name, destination string, visited map[string]bool) (string, bool, error) {
+	if visited[name] {
+		return "", false, fmt.Errorf("circular style composition: %v", name)
+	}
+	visited[name] = true
+	defer delete(visited, name)
+	var ret []string
+	found := false
+	for _, declaration := range splitDeclarations(s.namedStyles[name]) {
+		if !strings.HasPrefix(strings.ToLower(declaration), "composes:") {
+			continue
+		}
+		for _, ref := range strings.Fields(declaration[len("composes:"):]) {
+			definition, ok, err := s.destinationStyle(ref, destination, visited)
+			if err != nil {
+				return "", false, err
+			}
+			if definition != "" {
+				ret = append(ret, definition)
+			}
+			found = found || ok
+		}
+	}
+	definition, ok, err := s.namedStyle(name+"::"+destination, visited)
+	if err != nil {
+		return "", false, err
+	}
+	if definition != "" {
+		ret = append(ret, definition)
+	}
+	return strings.Join(ret, ";"), found || ok, nil
+}
+
+// addNamedStyles registers named style definitions
+func (s *Stylizer) addNamedStyles(styles map[string]string) {
+	if s.namedStyles == nil {
+		s.namedStyles = make(map[string]string)
+	}
+	for name, definition := range styles {
+		s.namedStyles[name] = definition
+	}
+}
+
+// WithStylesheet return option with css like stylesheet classes referenced by styleRef tag,
+// i.e. ".money { format: usd; text-align: right } .total { composes: money; font-style: bold }"
+func WithStylesheet(css string) Option {
+	return func(m *session) error {
+		styles, err := parseStylesheet(css)
+		if err != nil {
+			return err
+		}
+		m.stylizer.addNamedStyles(styles)
+		return nil
+	}
+}
+
+// WithStylesheetFile return option with stylesheet file loaded from file system, use os.DirFS for local files
+func WithStylesheetFile(fsys fs.FS, name string) Option {
+	return func(m *session) error {
+		data, err := fs.ReadFile(fsys, name)
+		if err != nil {
+			return fmt.Errorf("failed to load stylesheet: %v, %w", name, err)
+		}
+		return WithStylesheet(string(data))(m)
+	}
+}
@@ -0,0 +1,197 @@
+package xlsy
+
+import (
+	"bytes"
+	"github.com/stretchr/testify/assert"
+	"github.com/xuri/excelize/v2"
+	"testing"
+	"testing/fstest"
+)
+
+func TestParseStylesheet(t *testing.T) {
+	var testCases = []struct {
+		description string
+		css         string
+		expect      map[string]string
+		hasError    bool
+	}{
+		{
+			description: "classes with comments",
+			css: `
+/* money columns */
+.money { format: usd; text-align: right }
+.total, .grand { composes: money; font-style: bold; }
+`,
+			expect: map[string]string{
+				"money": "format:usd;text-align:right",
+				"total": "composes:money;font-style:bold",
+				"grand": "composes:money;font-style:bold",
+			},
+		},
+		{
+			description: "destination rules and cascading blocks",
+			css:         `.money{format:usd} .money::HEADER{text-align:right} .money{color:gray} .note{when:'a;b';color:red}`,
+			expect: map[string]string{
+				"money":         "format:usd;color:gray",
+				"money::header": "text-align:right",
+				"note":          "when:'a;b';color:red",
+			},
+		},
+		{
+			description: "unsupported selector",
+			css:         `td { color: red }`,
+			hasError:    true,
+		},
+		{
+			description: "unsupported destination",
+			css:         `.money::hover { color: red }`,
+			hasError:    true,
+		},
+		{
+			description: "missing block end",
+			css:         `.money { color: red`,
+			hasError:    true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		actual, err := parseStylesheet(testCase.css)
+		if testCase.hasError {
+			assert.NotNil(t, err, testCase.description)
+			continue
+		}
+		if !assert.Nil(t, err, testCase.description) {
+			continue
+		}
+		assert.EqualValues(t, testCase.expect, actual, testCase.description)
+	}
+}
+
+func TestMarshaller_Marshal_stylesheet(t *testing.T) {
+	css := `
+.money { format: usd; text-align: right }
+.money::header { text-align: right }
+.money::column { width: 120px }
+.total { composes: money; font-style: bold }
+.loop { composes: loop }
+.wide { color: blue }
+.wide::column { width: 90px }
+`
+	type Record struct {
+		Name   string  `xls:"styleRef=shaded"`
+		Amount float64 `xls:"styleRef=money"`
+		Total  float64 `xls:"style={color:red},styleRef=total"`
+	}
+	type Loop struct {
+		Amount float64 `xls:"styleRef=loop"`
+	}
+	type Missing struct {
+		Amount float64 `xls:"styleRef=unknown"`
+	}
+	type Wide struct {
+		Name   string
+		Amount float64 `xls:"styleRef=wide"`
+	}
+	fsys := fstest.MapFS{"styles/report.css": &fstest.MapFile{Data: []byte(css)}}
+	records := []Record{{Name: "a", Amount: 1, Total: 2}}
+
+	type cellStyle struct {
+		align  string
+		format string
+		bold   bool
+		color  string
+	}
+	var testCases = []struct {
+		description string
+		options     []Option
+		source      interface{}
+		expect      map[string]cellStyle
+		width       float64
+		hasError    bool
+	}{
+		{
+			description: "stylesheet file with named styles",
+			options:     []Option{WithStylesheetFile(fsys, "styles/report.css"), WithNamedStyles("shaded", "color:gray", "unused", "color:blue")},
+			source:      records,
+			expect: map[string]cellStyle{
+				"A2": {color: "808080"},
+				"B1": {align: "right", bold: true},
+				"B2": {align: "right", format: "$#,##0.00"},
+				"C1": {align: "right", bold: true},
+				"C2": {align: "right", format: "$#,##0.00", bold: true, color: "FF0000"},
+			},
+			width: 20,
+		},
+		{
+			description: "column rule without header rule",
+			options:     []Option{WithStylesheet(css)},
+			source:      []Wide{{Name: "a", Amount: 1}},
+			expect:      map[string]cellStyle{"B2": {color: "0000FF"}},
+			width:       15,
+		},
+		{
+			description: "circular composition",
+			options:     []Option{WithStylesheet(css)},
+			source:      []Loop{{Amount: 1}},
+			hasError:    true,
+		},
+		{
+			description: "missing class",
+			options:     []Option{WithStylesheet(css)},
+			source:      []Missing{{Amount: 1}},
+			hasError:    true,
+		},
+		{
+			description: "missing file",
+			options:     []Option{WithStylesheetFile(fsys, "styles/missing.css")},
+			source:      records,
+			hasError:    true,
+		},
+		{
+			description: "odd named styles",
+			options:     []Option{WithNamedStyles("shaded")},
+			source:      records,
+			hasError:    true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		data, err := NewMarshaller(testCase.options...).Marshal(testCase.source)
+		if testCase.hasError {
+			assert.NotNil(t, err, testCase.description)
+			continue
+		}
+		if !assert.Nil(t, err, testCase.description) {
+			continue
+		}
+		file, err := excelize.OpenReader(bytes.NewReader(data))
+		if !assert.Nil(t, err, testCase.description) {
+			continue
+		}
+		sheet := file.GetSheetName(0)
+		for cell, expect := range testCase.expect {
+			styleID, err := file.GetCellStyle(sheet, cell)
+			assert.Nil(t, err, testCase.description)
+			style, err := file.GetStyle(styleID)
+			if !assert.Nil(t, err, testCase.description) {
+				continue
+			}
+			actual := cellStyle{}
+			if style.Alignment != nil {
+				actual.align = style.Alignment.Horizontal
+			}
+			if style.CustomNumFmt != nil {
+				actual.format = *style.CustomNumFmt
+			}
+			if style.Font != nil {
+				actual.bold = style.Font.Bold
+				actual.color = style.Font.Color
+			}
+			assert.EqualValues(t, expect, actual, testCase.description+" "+cell)
+		}
+		width, err := file.GetColWidth(sheet, "B")
+		assert.Nil(t, err, testCase.description)
+		assert.EqualValues(t, testCase.width, width, testCase.description)
+		_ = file.Close()
+	}
+}
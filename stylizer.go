@@ -13,6 +13,9 @@ type Stylizer struct {
 	namedStyles        map[string]string
 	file               *excelize.File
 	registry           map[string]*Style
+	derived            map[int]*extStyle
+	conditions         map[string]*excelize.ConditionalFormatOptions
+	overlays           map[[2]int]int
 }
 
 func (s *Stylizer) styleDefinition(destination string, def string, refs string) (string, error) {
@@ -24,17 +27,12 @@ func (s *Stylizer) styleDefinition(destination string, def string, refs string)
 		return "", nil
 	}
 
-	if refs != "" {
-		for _, ref := range strings.Split(refs, " ") {
-			aStyle, ok := s.namedStyles[ref]
-			if !ok {
-				return "", fmt.Errorf("failed to lookup ref style: %s", aStyle)
-			}
-			if def != "" {
-				def += ";"
-			}
-			def += aStyle
-		}
+	if destination == "" {
+		destination = "cell"
+	}
+	def, err := s.refDefinition(destination, def, refs)
+	if err != nil {
+		return "", err
 	}
 
 	if defaultStyle == "" {
@@ -46,11 +44,80 @@ func (s *Stylizer) styleDefinition(destination string, def string, refs string)
 	return defaultStyle + ";" + def, nil
 }
 
+// refDefinition returns style definition extended with space separated named style references and their destination rules
+func (s *Stylizer) refDefinition(destination, def string, refs string) (string, error) {
+	for _, ref := range strings.Fields(refs) {
+		visited := map[string]bool{}
+		aStyle, ok, err := s.namedStyle(ref, visited)
+		if err != nil {
+			return "", err
+		}
+		destStyle, destOK, err := s.destinationStyle(ref, destination, visited)
+		if err != nil {
+			return "", err
+		}
+		if !ok && !destOK {
+			return "", fmt.Errorf("failed to lookup ref style: %s", ref)
+		}
+		for _, candidate := range []string{aStyle, destStyle} {
+			if candidate == "" {
+				continue
+			}
+			if def != "" {
+				def += ";"
+			}
+			def += candidate
+		}
+	}
+	return def, nil
+}
+
+// cascade returns destination rules (i.e. .money::header) of classes referenced by a cell style
+func (s *Stylizer) cascade(destination string, cellStyle *StyleTag) (string, error) {
+	if cellStyle == nil || cellStyle.Ref == "" {
+		return "", nil
+	}
+	var ret []string
+	for _, ref := range strings.Fields(cellStyle.Ref) {
+		definition, ok, err := s.destinationStyle(ref, destination, map[string]bool{})
+		if err != nil {
+			return "", err
+		}
+		if ok && definition != "" {
+			ret = append(ret, definition)
+		}
+	}
+	return strings.Join(ret, ";"), nil
+}
+
 // Style returns register style or nil
 func (s *Stylizer) Style(style string) *Style {
 	return s.registry[style]
 }
 
+// extStyle returns registered cell or header style with excelize style ID or nil
+func (s *Stylizer) extStyle(id int) *extStyle {
+	if style, ok := s.derived[id]; ok {
+		return style
+	}
+	for _, style := range s.registry {
+		for _, candidate := range []*extStyle{style.Cell, style.Header} {
+			if candidate != nil && candidate.ID != nil && *candidate.ID == id {
+				return candidate
+			}
+		}
+	}
+	return nil
+}
+
+// derive registers excelize style derived from registered style (i.e. with table borders)
+func (s *Stylizer) derive(id int, style *extStyle) {
+	if s.derived == nil {
+		s.derived = map[int]*extStyle{}
+	}
+	s.derived[id] = style
+}
+
 // Register register a style
 func (s *Stylizer) Register(style *Style) (err error) {
 	prev, ok := s.registry[style.Definition]
@@ -68,6 +135,9 @@ func (s *Stylizer) Register(style *Style) (err error) {
 	if err = style.Init(); err != nil {
 		return err
 	}
+	if style.When != "" {
+		return fmt.Errorf("invalid style: %v, when is supported by cond.style only", style.Definition)
+	}
 	s.registry[style.Definition] = style
 
 	if style.ID != "" {
@@ -5,6 +5,7 @@ import (
 	"github.com/viant/xunsafe"
 	"reflect"
 	"sort"
+	"unsafe"
 )
 
 const (
@@ -31,6 +32,8 @@ type (
 		Type        reflect.Type
 		IsStruct    bool
 		Cardinality int
+		source      interface{}
+		rowStyler   bool
 	}
 
 	indexPos []int
@@ -55,13 +58,14 @@ type (
 	}
 	//ColumnAddress represents acolumn
 	Column struct {
-		Position int
-		Name     string
-		Tag      *Tag
-		Field    *xunsafe.Field
-		xType    *xunsafe.Type
-		Table    *Table
-		size     int
+		Position   int
+		Name       string
+		Tag        *Tag
+		Field      *xunsafe.Field
+		xType      *xunsafe.Type
+		Table      *Table
+		size       int
+		validation *validation
 	}
 
 	//Columns represents columns
@@ -171,20 +175,21 @@ func (c *Column) ColumnStyleID(stylizer *Stylizer) *int {
 
 // Width returns width
 func (c *Column) Width(stylizer *Stylizer) *Length {
-	style := c.Tag.ColumnStyle
-	if style == nil {
-		return nil
-	}
-	if style.Style != "" {
-		if style := stylizer.Style(style.Style); style != nil && style.Column != nil {
-			width := style.Column.Width
-			if style.Column.WidthMax != nil {
-				if style.Column.WidthMax.Value() < width.Value() {
-					return style.Column.WidthMax
-				}
+	for _, styleTag := range []*StyleTag{c.Tag.ColumnStyle, c.Tag.CellStyle} {
+		if styleTag == nil || styleTag.Style == "" {
+			continue
+		}
+		style := stylizer.Style(styleTag.Style)
+		if style == nil || style.Column == nil || style.Column.Width == nil {
+			continue
+		}
+		width := style.Column.Width
+		if style.Column.WidthMax != nil {
+			if style.Column.WidthMax.Value() < width.Value() {
+				return style.Column.WidthMax
 			}
-			return style.Column.Width
 		}
+		return width
 	}
 	return nil
 }
@@ -196,13 +201,34 @@ func (c *Column) CellStyleID(stylizer *Stylizer) *int {
 	}
 	if style := c.Tag.CellStyle.Style; style != "" {
 		if style := stylizer.Style(style); style != nil && style.Cell.Style != nil {
-			return style.Header.ID
+			return style.Cell.ID
 		}
 	}
 
 	return nil
 }
 
+// recordValue returns column value of the record, ok is false for nil pointer value
+func (c *Column) recordValue(recordPtr unsafe.Pointer) (interface{}, bool) {
+	value := c.Field.Value(recordPtr)
+	if c.Field.Kind() == reflect.Ptr {
+		if (*unsafe.Pointer)(xunsafe.AsPointer(value)) == nil {
+			return nil, false
+		}
+		value = c.xType.Deref(value)
+	}
+	return value, true
+}
+
+// isOmitted returns true for omitempty column with nil record value, nil record (table without records) omits no column
+func (c *Column) isOmitted(recordPtr unsafe.Pointer) bool {
+	if !c.Tag.Omitempty || c.Tag.Blank || recordPtr == nil {
+		return false
+	}
+	_, ok := c.recordValue(recordPtr)
+	return !ok
+}
+
 // SheetName returns table cheed name
 func (t *Table) SheetName() string {
 	if t.Tag.WorkSheet != "" {
@@ -211,6 +237,14 @@ func (t *Table) SheetName() string {
 	return defaultSheetName
 }
 
+// firstRowHasValue returns true if the first table row has value at the index, headers of tables without rows are rendered from columns definitions
+func (t *Table) firstRowHasValue(index int) bool {
+	if len(t.Rows) == 0 {
+		return true
+	}
+	return index < len(t.Rows[0].Values) && t.Rows[0].Values[index].HasValue()
+}
+
 func (t *Table) IsStandalone() bool {
 	return t.Tag.WorkSheet != ""
 }
@@ -236,11 +270,24 @@ func (t *Table) newHeader(index, position int) *value {
 		t.Header = &Row{}
 	}
 	value := t.Header.Values.index(index)
-	t.indexPos.expand(position)
-	t.indexPos[position] = index
+	t.indexPos.expand(index)
+	t.indexPos[index] = position
 	return value
 }
 
+// IsFlat returns true for non-inverted slice table without nested tables
+func (t *Table) IsFlat() bool {
+	if t.IsStruct || t.Invert() {
+		return false
+	}
+	for _, column := range t.Columns {
+		if column.Table != nil && !column.Tag.Ignore && !column.Tag.Blank {
+			return false
+		}
+	}
+	return true
+}
+
 func (t *Table) UseRow(b bool) bool {
 	if t.Invert() {
 		return !b
@@ -256,6 +303,17 @@ func NewTable(rType reflect.Type, tableTag *Tag, aSession *session, parent *Colu
 	structType := ensureStruct(rType)
 	xStruct := xunsafe.NewStruct(structType)
 	var ret = &Table{Tag: tableTag, Stylizer: aSession.stylizer, Type: rType, Parent: parent, IsStruct: isStruct}
+	ret.rowStyler = ret.isRowStyler()
+	if style := tableTag.RowStyle; style != nil {
+		var err error
+		if style.Style, err = aSession.stylizer.refDefinition("row", style.Style, style.Ref); err != nil {
+			return nil, err
+		}
+		style.Ref = ""
+		if err = aSession.stylizer.Register(style.Definition()); err != nil {
+			return nil, err
+		}
+	}
 	ret.Columns = make(Columns, len(xStruct.Fields))
 	for i := range xStruct.Fields {
 		field := &xStruct.Fields[i]
@@ -267,6 +325,9 @@ func NewTable(rType reflect.Type, tableTag *Tag, aSession *session, parent *Colu
 		if fieldTag.Inverted == nil {
 			fieldTag.Inverted = tableTag.Inverted
 		}
+		if fieldTag.AutoWidth == nil {
+			fieldTag.AutoWidth = tableTag.AutoWidth
+		}
 		columnPos := int(field.Index)
 		if pos := fieldTag.Position; pos != nil {
 			columnPos = *pos
@@ -294,6 +355,12 @@ func NewTable(rType reflect.Type, tableTag *Tag, aSession *session, parent *Colu
 			continue
 		}
 
+		if err = column.cascadeStyles(aSession.stylizer); err != nil {
+			return nil, err
+		}
+		if err = column.initValidation(); err != nil {
+			return nil, err
+		}
 		if style := column.Tag.CellStyle; style != nil {
 			if style.Style, err = aSession.stylizer.styleDefinition(style.Destination, style.Style, style.Ref); err != nil {
 				return nil, err
@@ -318,10 +385,23 @@ func NewTable(rType reflect.Type, tableTag *Tag, aSession *session, parent *Colu
 			}
 		}
 		if style := column.Tag.ColumnStyle; style != nil {
+			if style.Style, err = aSession.stylizer.refDefinition("column", style.Style, style.Ref); err != nil {
+				return nil, err
+			}
+			style.Ref = ""
 			if err = aSession.stylizer.Register(style.Definition()); err != nil {
 				return nil, err
 			}
 		}
+		for _, condition := range column.Tag.Conditions {
+			if condition.Style, err = aSession.stylizer.refDefinition("cond", condition.Style, condition.Ref); err != nil {
+				return nil, err
+			}
+			condition.Ref = ""
+			if _, err = aSession.stylizer.conditionalFormat(condition.Style); err != nil {
+				return nil, err
+			}
+		}
 	}
 	sort.Slice(ret.Columns, func(i, j int) bool {
 		return ret.Columns[i].Position < ret.Columns[j].Position
@@ -339,3 +419,19 @@ func (c *Column) setName(columnTag *Tag, field *xunsafe.Field) {
 	}
 	c.Name = name
 }
+
+// cascadeStyles appends header and column destination rules of classes referenced by the cell style
+func (c *Column) cascadeStyles(stylizer *Stylizer) error {
+	for _, destination := range []string{"header", "column"} {
+		definition, err := stylizer.cascade(destination, c.Tag.CellStyle)
+		if err != nil {
+			return err
+		}
+		if definition == "" {
+			continue
+		}
+		styleTag := c.Tag.ensureDestination(&StyleTag{Destination: destination})
+		setStyle(&StyleTag{Style: definition}, styleTag)
+	}
+	return nil
+}
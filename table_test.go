@@ -0,0 +1,62 @@
+package xlsy
+
+import (
+	"bytes"
+	"github.com/stretchr/testify/assert"
+	"github.com/xuri/excelize/v2"
+	"testing"
+)
+
+func TestColumn_Width(t *testing.T) {
+	type Record struct {
+		Fixed   string `xls:"style={width:120px}"`
+		Clamped string `xls:"style={width:120px;width-max:60px}"`
+		Max     string `xls:"style={width-max:60px}"`
+		Column  string `xls:"column.style={width:60px}"`
+	}
+	data, err := NewMarshaller().Marshal([]*Record{{Fixed: "a", Clamped: "b", Max: "c", Column: "d"}})
+	if !assert.Nil(t, err) {
+		return
+	}
+	file, err := excelize.OpenReader(bytes.NewReader(data))
+	if !assert.Nil(t, err) {
+		return
+	}
+	defer file.Close()
+	defaultWidth, _ := file.GetColWidth(defaultSheetName, "Z")
+	for column, expect := range map[string]float64{"A": 20, "B": 10, "C": defaultWidth, "D": 10} {
+		width, err := file.GetColWidth(defaultSheetName, column)
+		assert.Nil(t, err, column)
+		assert.InDelta(t, expect, width, 0.01, column)
+	}
+}
+
+func TestColumn_CellStyleID(t *testing.T) {
+	type Record struct {
+		ID   int    `xls:"name=Id"`
+		Name string `xls:"style={color:red;text-align:center}"`
+	}
+	data, err := NewMarshaller().Marshal([]*Record{{ID: 1, Name: "a"}})
+	if !assert.Nil(t, err) {
+		return
+	}
+	file, err := excelize.OpenReader(bytes.NewReader(data))
+	if !assert.Nil(t, err) {
+		return
+	}
+	defer file.Close()
+	styleID, err := file.GetCellStyle(defaultSheetName, "B2")
+	if !assert.Nil(t, err) {
+		return
+	}
+	style, err := file.GetStyle(styleID)
+	if !assert.Nil(t, err) {
+		return
+	}
+	if assert.NotNil(t, style.Font) {
+		assert.EqualValues(t, "FF0000", style.Font.Color)
+	}
+	if assert.NotNil(t, style.Alignment) {
+		assert.EqualValues(t, "center", style.Alignment.Horizontal)
+	}
+}
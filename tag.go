@@ -20,19 +20,30 @@ type (
 
 	Tag struct {
 		*format.Tag
-		WorkSheet    string
-		HeaderStyle  *StyleTag
-		CellStyle    *StyleTag
-		ColumnStyle  *StyleTag
-		SheetPos     int
-		Blank        bool
-		Position     *int
-		Inverted     *bool //inverted orientation
-		First        bool
-		Row          int
-		Column       int
-		ColumnOffset int
-		RowOffset    int
+		WorkSheet     string
+		HeaderStyle   *StyleTag
+		CellStyle     *StyleTag
+		ColumnStyle   *StyleTag
+		Conditions    []*StyleTag //conditional formats, i.e. cond.style={when:"<0";color:red}
+		RowStyle      *StyleTag   //table records style, i.e. row.style={nth-child:even;background-color:#f2f2f2}
+		SheetPos      int
+		Blank         bool
+		Position      *int
+		Inverted      *bool //inverted orientation
+		AutoWidth     *bool //fit columns width to content
+		First         bool
+		Row           int
+		Column        int
+		ColumnOffset  int
+		RowOffset     int
+		Box           string //table outer border, i.e. "2px solid #000"
+		Grid          string //table inner border, i.e. "1px solid #ccc"
+		Freeze        string //frozen panes, i.e. header
+		FreezeColumns int    //leading key columns frozen with header
+		AutoFilter    bool   //table header auto filter
+		ExcelTable    string //excel table style, i.e. TableStyleMedium2, or true for default style
+		TableName     string //excel table name, worksheet name by default
+		Validation    string //data validation, i.e. validation={list:Open,Closed;error:'Pick a status'}
 	}
 )
 
@@ -56,6 +67,10 @@ func (t *Tag) updateStyles(styles []*StyleTag) {
 		return
 	}
 	for _, candidate := range styles {
+		if candidate.Destination == "cond" {
+			t.Conditions = append(t.Conditions, candidate)
+			continue
+		}
 		destStyle := t.ensureDestination(candidate)
 		setStyle(candidate, destStyle)
 
@@ -90,6 +105,11 @@ func (t *Tag) ensureDestination(candidate *StyleTag) *StyleTag {
 			t.ColumnStyle = &StyleTag{}
 		}
 		destStyle = t.ColumnStyle
+	case "row":
+		if t.RowStyle == nil {
+			t.RowStyle = &StyleTag{}
+		}
+		destStyle = t.RowStyle
 	default:
 		if t.CellStyle == nil {
 			t.CellStyle = &StyleTag{}
@@ -105,13 +125,13 @@ func setStyle(candidate, t *StyleTag) {
 		if t.Style != "" {
 			t.Style += ";"
 		}
-		t.Style = candidate.Style
+		t.Style += candidate.Style
 	}
-	if candidate.Style != "" {
+	if candidate.Ref != "" {
 		if t.Ref != "" {
-			t.Ref += ","
+			t.Ref += " "
 		}
-		t.Ref = candidate.Ref
+		t.Ref += candidate.Ref
 	}
 }
 
@@ -127,6 +147,29 @@ func (t *Tag) update(key, value string, styles *[]*StyleTag) error {
 		t.Inverted = &invert
 	case "first":
 		t.First = true
+	case "autowidth":
+		autoWidth := value == "true"
+		t.AutoWidth = &autoWidth
+	case "box":
+		t.Box = value
+	case "grid":
+		t.Grid = value
+	case "freeze":
+		if err := t.updateFreeze(value); err != nil {
+			return err
+		}
+	case "exceltable":
+		t.ExcelTable = value
+	case "tablename":
+		t.TableName = value
+	case "validation":
+		t.Validation = value
+	case "autofilter":
+		t.AutoFilter = value == "true"
+	case "freezecolumns":
+		if err := convertAndSetInt(&t.FreezeColumns, "freezeColumns", value); err != nil {
+			return err
+		}
 	case "row":
 		if err := convertAndSetInt(&t.Row, "row", value); err != nil {
 			return err
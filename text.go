@@ -0,0 +1,494 @@
+package xlsy
+
+import (
+	"context"
+	"fmt"
+	"github.com/xuri/excelize/v2"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type (
+	// textTable represents a table flattened for text rendering, nested table columns use dotted names
+	textTable struct {
+		layout  *textLayout
+		columns []*textColumn
+	}
+
+	textColumn struct {
+		name   string
+		column *Column
+	}
+
+	// textLayout represents visible table header slots
+	textLayout struct {
+		table *Table
+		slots []*textSlot
+		width int
+	}
+
+	textSlot struct {
+		index  int
+		column *Column
+		nested *textLayout
+	}
+)
+
+// textSheets returns worksheets with materialized tables rows for text rendering
+func (m *Marshaller) textSheets(ctx context.Context, any interface{}) ([]*workSheet, error) {
+	dest := excelize.NewFile()
+	defer dest.Close()
+	aSession, _, source, err := m.build(ctx, any, dest)
+	if err != nil {
+		return nil, err
+	}
+	var ret []*workSheet
+	for _, name := range aSession.names {
+		aSheet := aSession.sheets[name]
+		if err = m.setSourceData(ctx, aSheet); err != nil {
+			return nil, err
+		}
+		ret = append(ret, aSheet)
+	}
+	if source != nil && source.err != nil {
+		return nil, source.err
+	}
+	return ret, nil
+}
+
+func newTextTable(table *Table) *textTable {
+	ret := &textTable{}
+	ret.layout = ret.newLayout(table, "")
+	return ret
+}
+
+func (t *textTable) newLayout(table *Table, prefix string) *textLayout {
+	ret := &textLayout{table: table}
+	if table.Header == nil {
+		return ret
+	}
+	for i := range table.Header.Values {
+		column := table.columnByIndex(i)
+		if !table.isVisible(i, column) {
+			continue
+		}
+		slot := &textSlot{index: i, column: column}
+		ret.slots = append(ret.slots, slot)
+		if column.Table != nil {
+			name := prefix
+			if column.Name != "" {
+				name += column.Name + "."
+			}
+			slot.nested = t.newLayout(column.Table, name)
+			ret.width += slot.nested.width
+			continue
+		}
+		name := ""
+		if !column.Tag.Blank {
+			name = prefix + column.Name
+		}
+		t.columns = append(t.columns, &textColumn{name: name, column: column})
+		ret.width++
+	}
+	return ret
+}
+
+// isVisible returns false for standalone tables and omitempty columns without a value in the first row
+func (t *Table) isVisible(index int, column *Column) bool {
+	if column.Table != nil && column.Table.IsStandalone() {
+		return false
+	}
+	if !column.Tag.Omitempty {
+		return true
+	}
+	return len(t.Rows) > 0 && index < len(t.Rows[0].Values) && t.Rows[0].Values[index].HasValue()
+}
+
+// names returns flattened column names
+func (t *textTable) names() []string {
+	ret := make([]string, len(t.columns))
+	for i, column := range t.columns {
+		ret[i] = column.name
+	}
+	return ret
+}
+
+// lines returns table rows text, a row with nested tables rows spans multiple lines with repeated parent values
+func (t *textTable) lines(visitor func(line []string) error) error {
+	for _, row := range t.layout.table.Rows {
+		for _, cells := range t.layout.lines(row) {
+			line := make([]string, len(cells))
+			for i, cell := range cells {
+				if cell == nil || !cell.hasValue {
+					continue
+				}
+				line[i] = t.columns[i].column.text(t.layout.table.Stylizer, cell.value)
+			}
+			if err := visitor(line); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (l *textLayout) lines(row *Row) [][]*value {
+	height := 1
+	nested := make([][][]*value, len(l.slots))
+	for i, slot := range l.slots {
+		if slot.nested == nil || slot.index >= len(row.Values) {
+			continue
+		}
+		for _, nestedRow := range row.Values[slot.index].rows {
+			nested[i] = append(nested[i], slot.nested.lines(nestedRow)...)
+		}
+		if len(nested[i]) > height {
+			height = len(nested[i])
+		}
+	}
+	ret := make([][]*value, height)
+	for line := range ret {
+		ret[line] = make([]*value, 0, l.width)
+		for i, slot := range l.slots {
+			if slot.nested != nil {
+				if line < len(nested[i]) {
+					ret[line] = append(ret[line], nested[i][line]...)
+				} else {
+					ret[line] = append(ret[line], make([]*value, slot.nested.width)...)
+				}
+				continue
+			}
+			var cell *value
+			if slot.index < len(row.Values) {
+				cell = row.Values[slot.index]
+			}
+			ret[line] = append(ret[line], cell)
+		}
+	}
+	return ret
+}
+
+// numFormat returns column cell number format or empty string
+func (c *Column) numFormat(stylizer *Stylizer) string {
+	if c.Tag.CellStyle == nil || c.Tag.CellStyle.Style == "" {
+		return ""
+	}
+	style := stylizer.Style(c.Tag.CellStyle.Style)
+	if style == nil || style.Cell == nil || style.Cell.Style == nil || style.Cell.Style.CustomNumFmt == nil {
+		return ""
+	}
+	return *style.Cell.Style.CustomNumFmt
+}
+
+// text returns cell value text formatted with the column format style
+func (c *Column) text(stylizer *Stylizer, value interface{}) string {
+	numFmt := c.numFormat(stylizer)
+	switch actual := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return actual
+	case []byte:
+		return string(actual)
+	case time.Time:
+		if numFmt != "" {
+			return actual.Format(timeLayout(numFmt))
+		}
+		return c.Tag.FormatTime(&actual)
+	case *time.Time:
+		if actual == nil {
+			return ""
+		}
+		return c.text(stylizer, *actual)
+	}
+	rValue := reflect.ValueOf(value)
+	switch rValue.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if numFmt != "" {
+			return formatNumber(float64(rValue.Int()), numFmt)
+		}
+		return strconv.FormatInt(rValue.Int(), 10)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if numFmt != "" {
+			return formatNumber(float64(rValue.Uint()), numFmt)
+		}
+		return strconv.FormatUint(rValue.Uint(), 10)
+	case reflect.Float32, reflect.Float64:
+		if numFmt != "" {
+			return formatNumber(rValue.Float(), numFmt)
+		}
+		return strconv.FormatFloat(rValue.Float(), 'f', -1, rValue.Type().Bits())
+	case reflect.Bool:
+		return strconv.FormatBool(rValue.Bool())
+	}
+	return fmt.Sprint(value)
+}
+
+type (
+	// numberFormat represents excel number format with literal prefix and suffix, grouping, decimals and percentage
+	numberFormat struct {
+		prefix   string
+		suffix   string
+		decimals int
+		grouping bool
+		percent  bool
+	}
+
+	// dateToken represents excel date format token, kind is year, month, day, hour, minute, second or empty for literal text
+	dateToken struct {
+		kind    string
+		text    string
+		long    bool
+		textual bool
+	}
+)
+
+// formatSection returns excel number format first section, sections are separated by unquoted ;, i.e. #,##0.00;[Red]-#,##0.00
+func formatSection(numFmt string) string {
+	quoted := false
+	for i := 0; i < len(numFmt); i++ {
+		switch numFmt[i] {
+		case '"':
+			quoted = !quoted
+		case '\\':
+			if !quoted {
+				i++
+			}
+		case ';':
+			if !quoted {
+				return numFmt[:i]
+			}
+		}
+	}
+	return numFmt
+}
+
+// formatCodes returns format section with quoted and escaped literals, colors and locales replaced by spaces,
+// elapsed time [h], [mm] or [ss] keep their letters, offsets match the section
+func formatCodes(section string) string {
+	codes := []byte(section)
+	for i := 0; i < len(codes); i++ {
+		switch codes[i] {
+		case '"':
+			end := strings.IndexByte(section[i+1:], '"')
+			if end == -1 {
+				end = len(section) - i - 1
+			}
+			blank(codes[i : i+end+2])
+			i += end + 1
+		case '\\', '_', '*':
+			codes[i] = ' '
+			if i+1 < len(codes) {
+				i++
+				codes[i] = ' '
+			}
+		case '[':
+			end := strings.IndexByte(section[i:], ']')
+			if end == -1 {
+				end = len(section) - i - 1
+			}
+			if strings.Trim(strings.ToLower(section[i+1:i+end]), "hms") == "" {
+				codes[i], codes[i+end] = ' ', ' '
+			} else {
+				blank(codes[i : i+end+1])
+			}
+			i += end
+		}
+	}
+	return string(codes)
+}
+
+func blank(codes []byte) {
+	for i := range codes {
+		codes[i] = ' '
+	}
+}
+
+// formatLiteral returns format section literal text without quotes, escapes, paddings, colors and locales
+func formatLiteral(text string) string {
+	var ret strings.Builder
+	for i := 0; i < len(text); i++ {
+		switch text[i] {
+		case '"':
+			end := strings.IndexByte(text[i+1:], '"')
+			if end == -1 {
+				end = len(text) - i - 1
+			}
+			ret.WriteString(text[i+1 : i+1+end])
+			i += end + 1
+		case '\\':
+			if i+1 < len(text) {
+				i++
+				ret.WriteByte(text[i])
+			}
+		case '_':
+			i++
+			ret.WriteByte(' ')
+		case '*':
+			i++
+		case '[':
+			if end := strings.IndexByte(text[i:], ']'); end != -1 {
+				i += end
+			} else {
+				i = len(text)
+			}
+		case ']':
+		default:
+			ret.WriteByte(text[i])
+		}
+	}
+	return ret.String()
+}
+
+// parseNumberFormat parses excel number format first section or returns nil if format has no digit placeholder
+func parseNumberFormat(numFmt string) *numberFormat {
+	section := formatSection(numFmt)
+	codes := formatCodes(section)
+	begin := strings.IndexAny(codes, "#0")
+	end := strings.LastIndexAny(codes, "#0")
+	if begin == -1 {
+		return nil
+	}
+	mask := codes[begin : end+1]
+	ret := &numberFormat{prefix: formatLiteral(section[:begin]), suffix: formatLiteral(section[end+1:]), grouping: strings.Contains(mask, ",")}
+	ret.percent = strings.Contains(codes[end+1:], "%")
+	if index := strings.Index(mask, "."); index != -1 {
+		ret.decimals = len(mask) - index - 1
+	}
+	return ret
+}
+
+func (f *numberFormat) format(number float64) string {
+	if f.percent {
+		number *= 100
+	}
+	sign := ""
+	if number < 0 {
+		sign = "-"
+		number = -number
+	}
+	text := strconv.FormatFloat(math.Round(number*math.Pow10(f.decimals))/math.Pow10(f.decimals), 'f', f.decimals, 64)
+	if f.grouping {
+		integer, fraction := text, ""
+		if index := strings.Index(text, "."); index != -1 {
+			integer, fraction = text[:index], text[index:]
+		}
+		var grouped strings.Builder
+		for i, digit := range integer {
+			if i > 0 && (len(integer)-i)%3 == 0 {
+				grouped.WriteByte(',')
+			}
+			grouped.WriteRune(digit)
+		}
+		text = grouped.String() + fraction
+	}
+	return sign + f.prefix + text + f.suffix
+}
+
+// formatNumber formats number with excel number format
+func formatNumber(number float64, numFmt string) string {
+	format := parseNumberFormat(numFmt)
+	if format == nil {
+		return strconv.FormatFloat(number, 'f', -1, 64)
+	}
+	return format.format(number)
+}
+
+// isDateFormat returns true if excel number format first section has date codes outside literals
+func isDateFormat(numFmt string) bool {
+	return strings.ContainsAny(strings.ToLower(formatCodes(formatSection(numFmt))), "ymdhs")
+}
+
+// dateTokens splits excel date format first section into tokens, m following an hour is a minute
+func dateTokens(numFmt string) []*dateToken {
+	var ret []*dateToken
+	hour := false
+	section := formatSection(numFmt)
+	codes := formatCodes(section)
+	for i := 0; i < len(codes); {
+		if codes[i] == ' ' {
+			end := i + 1
+			for end < len(codes) && codes[end] == ' ' {
+				end++
+			}
+			if text := formatLiteral(section[i:end]); text != "" {
+				ret = append(ret, &dateToken{text: text})
+			}
+			i = end
+			continue
+		}
+		text := codes[i : i+1]
+		for j := i + 1; j < len(codes) && strings.EqualFold(codes[j:j+1], codes[i:i+1]); j++ {
+			text = codes[i : j+1]
+		}
+		i += len(text)
+		token := &dateToken{text: text, long: len(text) > 1}
+		ret = append(ret, token)
+		switch strings.ToLower(text[:1]) {
+		case "y":
+			token.kind = "year"
+			token.long = len(text) > 2
+		case "m":
+			token.kind = "month"
+			if hour && len(text) < 3 {
+				token.kind = "minute"
+			}
+			token.textual = len(text) > 2
+			if token.textual {
+				token.long = len(text) > 3
+			}
+		case "d":
+			token.kind = "day"
+		case "h":
+			token.kind = "hour"
+			hour = true
+			continue
+		case "s":
+			token.kind = "second"
+		case ":":
+			if hour {
+				continue
+			}
+		}
+		hour = false
+	}
+	return ret
+}
+
+// timeLayout converts excel date format to go time layout
+func timeLayout(numFmt string) string {
+	var layout strings.Builder
+	for _, token := range dateTokens(numFmt) {
+		switch token.kind {
+		case "year":
+			layout.WriteString(choose(token.long, "2006", "06"))
+		case "month":
+			if token.textual {
+				layout.WriteString(choose(token.long, "January", "Jan"))
+			} else {
+				layout.WriteString(choose(token.long, "01", "1"))
+			}
+		case "day":
+			layout.WriteString(choose(token.long, "02", "2"))
+		case "hour":
+			layout.WriteString("15")
+		case "minute":
+			layout.WriteString(choose(token.long, "04", "4"))
+		case "second":
+			layout.WriteString(choose(token.long, "05", "5"))
+		default:
+			layout.WriteString(token.text)
+		}
+	}
+	return layout.String()
+}
+
+func choose(condition bool, ok, otherwise string) string {
+	if condition {
+		return ok
+	}
+	return otherwise
+}
@@ -0,0 +1,348 @@
+package xlsy
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/viant/xreflect"
+	"github.com/xuri/excelize/v2"
+	"reflect"
+)
+
+// headerScanLimit defines max number of rows scanned to locate a table header
+const headerScanLimit = 20
+
+type (
+	// Unmarshaller represents xls unmarshaller
+	Unmarshaller struct {
+		session []Option
+	}
+
+	tableLayout struct {
+		table   *Table
+		path    string
+		header  Cursor
+		height  int
+		columns []*columnLayout
+		matched int
+	}
+
+	columnLayout struct {
+		column *Column
+		offset int
+		nested *tableLayout
+	}
+)
+
+// Unmarshal unmarshal xls data into dest
+func (u *Unmarshaller) Unmarshal(data []byte, dest interface{}) error {
+	src, err := excelize.OpenReader(bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+	destValue := reflect.ValueOf(dest)
+	if destValue.Kind() != reflect.Ptr || destValue.IsNil() {
+		return fmt.Errorf("unsupported dest type: %T, expected non nil pointer", dest)
+	}
+	stylizer := &Stylizer{registry: map[string]*Style{}, file: src}
+	aSession := newSession(nil, stylizer, NewTag())
+	if err = aSession.apply(u.session); err != nil {
+		return err
+	}
+	destValue = destValue.Elem()
+	switch destValue.Kind() {
+	case reflect.Slice:
+		err = u.readSheet(src, destValue, aSession)
+	case reflect.Struct:
+		err = u.readSheets(src, destValue, aSession)
+	default:
+		return fmt.Errorf("unsupported type: %T", dest)
+	}
+	return aSession.decodingError(err)
+}
+
+// readSheets reads each struct or slice holder field from its own worksheet, missing worksheets are reported with MissingSheetError
+func (u *Unmarshaller) readSheets(src *excelize.File, dest reflect.Value, parent *session) error {
+	var missing []string
+	fields := sheetFields(dest.Type())
+	for i := range fields {
+		field := &fields[i]
+		fieldType := field.Type
+		if fieldType.Kind() == reflect.Ptr {
+			fieldType = fieldType.Elem()
+		}
+		switch fieldType.Kind() {
+		case reflect.Struct:
+			if fieldType == xreflect.TimeType {
+				continue
+			}
+		case reflect.Slice:
+		default:
+			continue
+		}
+		fieldValue := dest.Field(int(field.Index))
+		if !fieldValue.CanSet() {
+			continue
+		}
+		tag, err := parseTag(field.Tag)
+		if err != nil {
+			return err
+		}
+		if tag.Ignore {
+			continue
+		}
+		if tag.WorkSheet == "" {
+			tag.WorkSheet = field.Name
+		}
+		if index, _ := src.GetSheetIndex(tag.WorkSheet); index == -1 {
+			missing = append(missing, tag.WorkSheet)
+			continue
+		}
+		aSession := newSession(parent, parent.stylizer, tag)
+		if err = u.readSheet(src, fieldValue, aSession); err != nil {
+			return err
+		}
+	}
+	if len(missing) > 0 {
+		return &MissingSheetError{Sheets: missing}
+	}
+	return nil
+}
+
+func (u *Unmarshaller) readSheet(src *excelize.File, dest reflect.Value, aSession *session) error {
+	aTable, err := NewTable(dest.Type(), aSession.tag, aSession, nil)
+	if err != nil {
+		return err
+	}
+	if err = u.validateOrientation(aTable); err != nil {
+		return err
+	}
+	aGrid, err := newGrid(src, aTable.SheetName())
+	if err != nil {
+		return err
+	}
+	if aTable.Invert() {
+		aGrid = aGrid.transpose()
+	}
+	layout := u.locateTable(aTable, aGrid)
+	if layout == nil {
+		return fmt.Errorf("failed to locate %s table header in sheet: %s", aTable.Type, aGrid.sheet)
+	}
+	return u.readTable(layout, aGrid, layout.header.row()+layout.height, aGrid.height(), dest, aSession)
+}
+
+// validateOrientation checks that nested tables share parent table orientation
+func (u *Unmarshaller) validateOrientation(aTable *Table) error {
+	for _, column := range aTable.Columns {
+		if column.Table == nil || column.Table.IsStandalone() || column.Tag.Ignore || column.Tag.Blank {
+			continue
+		}
+		if column.Table.Invert() != aTable.Invert() {
+			return fmt.Errorf("unsupported nested table %s orientation: %v", column.Field.Name, column.Table.Invert())
+		}
+		if err := u.validateOrientation(column.Table); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// locateTable scans rows below table address and returns the layout best matching table columns,
+// for inverted table grid is already transposed
+func (u *Unmarshaller) locateTable(aTable *Table, aGrid *grid) *tableLayout {
+	begin := Cursor(0)
+	aTable.Tag.adjustAddress(&begin)
+	if aTable.Invert() {
+		begin = newCursor(begin.column(), begin.row())
+	}
+	path := aTable.Type.String()
+	if structType := ensureStruct(aTable.Type); structType != nil && structType.Name() != "" {
+		path = structType.Name()
+	}
+	var ret *tableLayout
+	for row := begin.row(); row < aGrid.height() && row < begin.row()+headerScanLimit; row++ {
+		candidate := u.matchHeader(aTable, aGrid, row, begin.column(), -1, path)
+		if ret == nil || candidate.matched > ret.matched {
+			ret = candidate
+		}
+	}
+	if ret == nil || ret.matched == 0 {
+		return nil
+	}
+	return ret
+}
+
+// matchHeader matches table columns with header cells of the supplied row in [begin, end) column range
+func (u *Unmarshaller) matchHeader(aTable *Table, aGrid *grid, row, begin, end int, path string) *tableLayout {
+	ret := &tableLayout{table: aTable, path: path, header: newCursor(row, begin), height: 1}
+	next := begin
+	for _, column := range aTable.Columns {
+		if column.Tag.Ignore {
+			continue
+		}
+		if column.Tag.Blank {
+			next++
+			continue
+		}
+		if column.Table != nil && column.Table.IsStandalone() {
+			continue
+		}
+		offset := -1
+		if column.Name != "" {
+			offset = aGrid.find(row, begin, end, column.Name)
+		}
+		if offset == -1 {
+			if column.Name != "" {
+				continue
+			}
+			offset = next
+		} else {
+			ret.matched++
+		}
+		item := &columnLayout{column: column, offset: offset}
+		ret.columns = append(ret.columns, item)
+		next = offset + 1
+		if column.Table == nil {
+			continue
+		}
+		childRow := row
+		if !aTable.Inline {
+			childRow++
+		}
+		childEnd := end
+		if merged, ok := aGrid.merged[newCursor(row, offset)]; ok && merged.column() >= offset {
+			childEnd = merged.column() + 1
+		}
+		if childRow >= aGrid.height() {
+			continue
+		}
+		item.nested = u.matchHeader(column.Table, aGrid, childRow, offset, childEnd, path+"."+column.Field.Name)
+		ret.matched += item.nested.matched
+		if height := childRow - row + item.nested.height; height > ret.height {
+			ret.height = height
+		}
+		if last := item.nested.lastOffset(); last >= next {
+			next = last + 1
+		}
+	}
+	return ret
+}
+
+// readTable reads table records from [begin, end) rows range into dest slice, struct or struct pointer
+func (u *Unmarshaller) readTable(layout *tableLayout, aGrid *grid, begin, end int, dest reflect.Value, aSession *session) error {
+	if dest.Kind() != reflect.Slice {
+		for row := begin; row < end; row++ {
+			if layout.isEmpty(aGrid, row, true) {
+				continue
+			}
+			return u.readRecord(layout, aGrid, row, end, u.ensureRecord(dest), aSession)
+		}
+		return nil
+	}
+	itemType := dest.Type().Elem()
+	rows := layout.recordRows(aGrid, begin, end)
+	for i, row := range rows {
+		next := end
+		if i+1 < len(rows) {
+			next = rows[i+1]
+		}
+		item := reflect.New(itemType).Elem()
+		if err := u.readRecord(layout, aGrid, row, next, u.ensureRecord(item), aSession); err != nil {
+			return err
+		}
+		dest.Set(reflect.Append(dest, item))
+	}
+	return nil
+}
+
+// ensureRecord returns settable struct value, allocating pointer if needed
+func (u *Unmarshaller) ensureRecord(dest reflect.Value) reflect.Value {
+	if dest.Kind() != reflect.Ptr {
+		return dest
+	}
+	if dest.IsNil() {
+		dest.Set(reflect.New(dest.Type().Elem()))
+	}
+	return dest.Elem()
+}
+
+// readRecord reads a record starting at row, nested tables are read from [row, end) rows range
+func (u *Unmarshaller) readRecord(layout *tableLayout, aGrid *grid, row, end int, record reflect.Value, aSession *session) error {
+	for _, item := range layout.columns {
+		field := record.Field(int(item.column.Field.Index))
+		if !field.CanSet() {
+			continue
+		}
+		if item.nested != nil {
+			if err := u.readTable(item.nested, aGrid, row, end, field, aSession); err != nil {
+				return err
+			}
+			continue
+		}
+		cell := newCursor(row, item.offset)
+		text := aGrid.value(cell)
+		if err := setCellValue(field, text, item.column.Tag, aGrid.date1904); err != nil {
+			cellErr := &CellError{Sheet: aGrid.sheet, Cell: aGrid.address(cell), Header: item.column.Name, Field: layout.path + "." + item.column.Field.Name, Value: text, Err: err}
+			if err = aSession.reportCellError(cellErr); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// recordRows returns rows in [begin, end) range where a new record starts
+func (l *tableLayout) recordRows(aGrid *grid, begin, end int) []int {
+	nested := !l.hasLeafColumns()
+	var ret []int
+	for row := begin; row < end; row++ {
+		if !l.isEmpty(aGrid, row, nested) {
+			ret = append(ret, row)
+		}
+	}
+	return ret
+}
+
+func (l *tableLayout) hasLeafColumns() bool {
+	for _, item := range l.columns {
+		if item.nested == nil {
+			return true
+		}
+	}
+	return false
+}
+
+// isEmpty returns true if record cells in the row are empty, nested flag includes nested tables cells
+func (l *tableLayout) isEmpty(aGrid *grid, row int, nested bool) bool {
+	for _, item := range l.columns {
+		if item.nested != nil {
+			if nested && !item.nested.isEmpty(aGrid, row, nested) {
+				return false
+			}
+			continue
+		}
+		if aGrid.value(newCursor(row, item.offset)) != "" {
+			return false
+		}
+	}
+	return true
+}
+
+func (l *tableLayout) lastOffset() int {
+	ret := l.header.column()
+	for _, item := range l.columns {
+		offset := item.offset
+		if item.nested != nil {
+			offset = item.nested.lastOffset()
+		}
+		if offset > ret {
+			ret = offset
+		}
+	}
+	return ret
+}
+
+// NewUnmarshaller creates an unmarshaller with options
+func NewUnmarshaller(opts ...Option) *Unmarshaller {
+	return &Unmarshaller{session: opts}
+}
@@ -0,0 +1,232 @@
+package xlsy
+
+import (
+	"bytes"
+	"github.com/stretchr/testify/assert"
+	"github.com/xuri/excelize/v2"
+	"testing"
+	"time"
+)
+
+func TestUnmarshaller_Unmarshal(t *testing.T) {
+	now := time.Now().UTC().Truncate(time.Second)
+
+	type Record struct {
+		ID      int    `xls:"name=Id"`
+		Name    string `xls:"name=Name"`
+		Note    string `xls:"-"`
+		Amount  float64
+		Active  bool
+		Started time.Time `xls:"style={format:date}"`
+	}
+
+	type Item struct {
+		Sequence    int `xls:"Seq"`
+		Description string
+		Product     string
+	}
+	type Order struct {
+		ID     int
+		Name   string
+		Amount float64
+		List   []*Item
+		Info   string
+	}
+	orders := []*Order{
+		{ID: 1, Name: "Name 1", Amount: 3.2, List: []*Item{{Sequence: 1, Product: "P1"}, {Sequence: 3, Description: "D2", Product: "P2"}}, Info: "info 1"},
+		{ID: 2, Name: "Name 2", Amount: 6.5, Info: "info 2"},
+		{ID: 3, Name: "Name 3", Amount: 1.5, List: []*Item{{Sequence: 1, Product: "P4"}}, Info: "info 3"},
+	}
+
+	type Summary struct {
+		Report string
+		Total  float64
+	}
+	type Holder struct {
+		Orders  []*Order
+		Summary Summary `xls:"worksheet=Totals"`
+	}
+	type Info struct {
+		Report string
+		From   string
+		To     string
+	}
+	type InfoHolder struct {
+		Info   Info `xls:"invert=true"`
+		Orders []*Order
+	}
+	type ArchiveHolder struct {
+		Orders  []*Order
+		Archive []*Order `xls:"worksheet=Archive"`
+	}
+
+	type Line struct {
+		ID       int
+		Product  string
+		Quantity int `xls:"name=Qty"`
+	}
+	lines := [][]interface{}{
+		{"ID", "Product", "Qty"},
+		{1, "P1", 3},
+		{2, "P2", "abc"},
+		{"x3", "P3", "1.5"},
+	}
+
+	var testCases = []struct {
+		description string
+		options     []Option
+		source      func() ([]byte, error)
+		dest        func() interface{}
+		expect      interface{}
+		expectErr   string
+	}{
+		{
+			description: "slice round trip",
+			source: func() ([]byte, error) {
+				return NewMarshaller().Marshal([]*Record{
+					{ID: 1, Name: "name 1", Note: "skip", Amount: 3.2, Active: true, Started: now},
+					{ID: 2, Name: "name 2", Amount: 1231232312.4444, Started: now.Add(time.Hour)},
+				})
+			},
+			dest: func() interface{} { return &[]*Record{} },
+			expect: &[]*Record{
+				{ID: 1, Name: "name 1", Amount: 3.2, Active: true, Started: now},
+				{ID: 2, Name: "name 2", Amount: 1231232312.4444, Started: now.Add(time.Hour)},
+			},
+		},
+		{
+			description: "nested relation round trip",
+			source: func() ([]byte, error) {
+				return NewMarshaller().Marshal(orders)
+			},
+			dest:   func() interface{} { return &[]*Order{} },
+			expect: &orders,
+		},
+		{
+			description: "multi sheet holder",
+			source: func() ([]byte, error) {
+				return NewMarshaller().Marshal(&Holder{Orders: orders, Summary: Summary{Report: "Total", Total: 11.2}})
+			},
+			dest:   func() interface{} { return &Holder{} },
+			expect: &Holder{Orders: orders, Summary: Summary{Report: "Total", Total: 11.2}},
+		},
+		{
+			description: "multi sheet holder with missing sheet",
+			source: func() ([]byte, error) {
+				return NewMarshaller().Marshal(&Holder{Orders: orders, Summary: Summary{Report: "Total", Total: 11.2}})
+			},
+			dest:      func() interface{} { return &ArchiveHolder{} },
+			expect:    &ArchiveHolder{Orders: orders},
+			expectErr: "missing worksheets: Archive",
+		},
+		{
+			description: "inverted nested relation round trip",
+			source: func() ([]byte, error) {
+				return NewMarshaller(WithInverted()).Marshal(orders)
+			},
+			options: []Option{WithInverted()},
+			dest:    func() interface{} { return &[]*Order{} },
+			expect:  &orders,
+		},
+		{
+			description: "inverted info sheet",
+			source: func() ([]byte, error) {
+				return NewMarshaller().Marshal(&InfoHolder{Info: Info{Report: "Total", From: "2023-08-01", To: "2023-08-02"}, Orders: orders})
+			},
+			dest:   func() interface{} { return &InfoHolder{} },
+			expect: &InfoHolder{Info: Info{Report: "Total", From: "2023-08-01", To: "2023-08-02"}, Orders: orders},
+		},
+		{
+			description: "cell error",
+			source: func() ([]byte, error) {
+				return newTestWorkbook("Lines", lines)
+			},
+			options:   []Option{WithTag(&Tag{WorkSheet: "Lines"})},
+			dest:      func() interface{} { return &[]*Line{} },
+			expect:    &[]*Line{{ID: 1, Product: "P1", Quantity: 3}},
+			expectErr: `Sheet "Lines" C3 (Qty): cannot convert "abc" to int (field Line.Quantity)`,
+		},
+		{
+			description: "collected cell errors",
+			source: func() ([]byte, error) {
+				return newTestWorkbook("Lines", lines)
+			},
+			options: []Option{WithTag(&Tag{WorkSheet: "Lines"}), WithCollectErrors()},
+			dest:    func() interface{} { return &[]*Line{} },
+			expect:  &[]*Line{{ID: 1, Product: "P1", Quantity: 3}, {ID: 2, Product: "P2"}, {Product: "P3"}},
+			expectErr: `Sheet "Lines" C3 (Qty): cannot convert "abc" to int (field Line.Quantity)
+Sheet "Lines" A4 (ID): cannot convert "x3" to int (field Line.ID)
+Sheet "Lines" C4 (Qty): cannot convert "1.5" to int (field Line.Quantity)`,
+		},
+		{
+			description: "customer edited sheet",
+			source: func() ([]byte, error) {
+				return newTestWorkbook("Sheet1", [][]interface{}{
+					{"Monthly report"},
+					{},
+					{"Name", "Comment", "Id", "Started"},
+					{"name 1", "extra", 1, "2023-08-01"},
+					{},
+					{"name 2", nil, "2", nil},
+				})
+			},
+			dest: func() interface{} {
+				type Item struct {
+					ID      *int `xls:"name=Id"`
+					Name    string
+					Started *time.Time
+				}
+				return &[]Item{}
+			},
+			expect: func() interface{} {
+				type Item struct {
+					ID      *int `xls:"name=Id"`
+					Name    string
+					Started *time.Time
+				}
+				started := time.Date(2023, 8, 1, 0, 0, 0, 0, time.UTC)
+				return &[]Item{{ID: intPtr(1), Name: "name 1", Started: &started}, {ID: intPtr(2), Name: "name 2"}}
+			}(),
+		},
+	}
+
+	for _, testCase := range testCases {
+		data, err := testCase.source()
+		if !assert.Nil(t, err, testCase.description) {
+			continue
+		}
+		dest := testCase.dest()
+		err = NewUnmarshaller(testCase.options...).Unmarshal(data, dest)
+		if testCase.expectErr != "" {
+			assert.EqualError(t, err, testCase.expectErr, testCase.description)
+		} else if !assert.Nil(t, err, testCase.description) {
+			continue
+		}
+		assert.EqualValues(t, testCase.expect, dest, testCase.description)
+	}
+}
+
+func newTestWorkbook(sheet string, rows [][]interface{}) ([]byte, error) {
+	file := excelize.NewFile()
+	if sheet != defaultSheetName {
+		if _, err := file.NewSheet(sheet); err != nil {
+			return nil, err
+		}
+		_ = file.DeleteSheet(defaultSheetName)
+	}
+	for i, row := range rows {
+		for j, item := range row {
+			if item == nil {
+				continue
+			}
+			if err := file.SetCellValue(sheet, newCursor(i, j).String(), item); err != nil {
+				return nil, err
+			}
+		}
+	}
+	buffer := new(bytes.Buffer)
+	if err := file.Write(buffer); err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
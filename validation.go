@@ -0,0 +1,284 @@
+package xlsy
+
+import (
+	"fmt"
+	"github.com/viant/xreflect"
+	"github.com/xuri/excelize/v2"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Enumerator represents a column value type providing allowed values, rendered as data validation drop-down list
+type Enumerator interface {
+	Enumerate() []string
+}
+
+var enumeratorType = reflect.TypeOf((*Enumerator)(nil)).Elem()
+
+// validation represents column data validation, i.e. validation={list:Open,Closed;error:'Pick a status'}
+type validation struct {
+	List        []string
+	Type        string //whole, decimal, date or time, inferred from field type by default
+	Min         string
+	Max         string
+	MinLength   string
+	MaxLength   string
+	Error       string
+	ErrorTitle  string
+	ErrorStyle  excelize.DataValidationErrorStyle
+	Prompt      string
+	PromptTitle string
+	AllowBlank  bool
+}
+
+var validationTypes = map[string]excelize.DataValidationType{
+	"whole":   excelize.DataValidationTypeWhole,
+	"decimal": excelize.DataValidationTypeDecimal,
+	"date":    excelize.DataValidationTypeDate,
+	"time":    excelize.DataValidationTypeTime,
+}
+
+var validationErrorStyles = map[string]excelize.DataValidationErrorStyle{
+	"stop":        excelize.DataValidationErrorStyleStop,
+	"warning":     excelize.DataValidationErrorStyleWarning,
+	"information": excelize.DataValidationErrorStyleInformation,
+}
+
+// parseValidation parses validation definition declarations
+func parseValidation(definition string) (*validation, error) {
+	ret := &validation{AllowBlank: true, ErrorStyle: excelize.DataValidationErrorStyleStop}
+	for _, declaration := range splitDeclarations(definition) {
+		index := strings.Index(declaration, ":")
+		key, value := strings.ToLower(declaration[:index]), strings.Trim(declaration[index+1:], `"'`)
+		if err := ret.update(key, value); err != nil {
+			return nil, fmt.Errorf("invalid validation: %v, %w", definition, err)
+		}
+	}
+	if err := ret.validate(); err != nil {
+		return nil, fmt.Errorf("invalid validation: %v, %w", definition, err)
+	}
+	return ret, nil
+}
+
+func (v *validation) update(key, value string) error {
+	switch key {
+	case "list":
+		for _, item := range strings.Split(value, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				v.List = append(v.List, item)
+			}
+		}
+	case "type":
+		value = strings.ToLower(value)
+		if _, ok := validationTypes[value]; !ok {
+			return fmt.Errorf("unsupported type: %v", value)
+		}
+		v.Type = value
+	case "min":
+		v.Min = value
+	case "max":
+		v.Max = value
+	case "min-length":
+		v.MinLength = value
+	case "max-length":
+		v.MaxLength = value
+	case "error":
+		v.Error = value
+	case "error-title":
+		v.ErrorTitle = value
+	case "error-style":
+		style, ok := validationErrorStyles[strings.ToLower(value)]
+		if !ok {
+			return fmt.Errorf("unsupported error-style: %v", value)
+		}
+		v.ErrorStyle = style
+	case "prompt":
+		v.Prompt = value
+	case "prompt-title":
+		v.PromptTitle = value
+	case "allow-blank":
+		v.AllowBlank = value != "false"
+	default:
+		return fmt.Errorf("unsupported key: %v", key)
+	}
+	return nil
+}
+
+func (v *validation) validate() error {
+	criteria := 0
+	if len(v.List) > 0 {
+		criteria++
+	}
+	if v.Min != "" || v.Max != "" {
+		criteria++
+	}
+	if v.MinLength != "" || v.MaxLength != "" {
+		criteria++
+	}
+	if criteria > 1 {
+		return fmt.Errorf("expected one of list, min/max or min-length/max-length")
+	}
+	return nil
+}
+
+// dataValidation returns excelize data validation over sqref range
+func (v *validation) dataValidation(sqref string) (*excelize.DataValidation, error) {
+	ret := excelize.NewDataValidation(v.AllowBlank)
+	ret.SetSqref(sqref)
+	var err error
+	switch {
+	case len(v.List) > 0:
+		err = ret.SetDropList(v.List)
+	case v.Min != "" || v.Max != "":
+		err = v.setRange(ret, validationTypes[v.Type], v.Min, v.Max)
+	case v.MinLength != "" || v.MaxLength != "":
+		err = v.setRange(ret, excelize.DataValidationTypeTextLength, v.MinLength, v.MaxLength)
+	default:
+		return nil, fmt.Errorf("invalid validation: missing list, min/max or min-length/max-length")
+	}
+	if err != nil {
+		return nil, err
+	}
+	if v.Error != "" || v.ErrorTitle != "" {
+		ret.SetError(v.ErrorStyle, v.ErrorTitle, v.Error)
+	}
+	if v.Prompt != "" || v.PromptTitle != "" {
+		ret.SetInput(v.PromptTitle, v.Prompt)
+	}
+	return ret, nil
+}
+
+// setRange sets between, greater or equal min, or less or equal max criteria
+func (v *validation) setRange(dv *excelize.DataValidation, validationType excelize.DataValidationType, min, max string) error {
+	from, err := validationFormula(validationType, min)
+	if err != nil {
+		return err
+	}
+	to, err := validationFormula(validationType, max)
+	if err != nil {
+		return err
+	}
+	switch {
+	case min != "" && max != "":
+		return dv.SetRange(from, to, validationType, excelize.DataValidationOperatorBetween)
+	case min != "":
+		err = dv.SetRange(from, from, validationType, excelize.DataValidationOperatorGreaterThanOrEqual)
+	default:
+		err = dv.SetRange(to, to, validationType, excelize.DataValidationOperatorLessThanOrEqual)
+	}
+	dv.Formula2 = ""
+	return err
+}
+
+// validationFormula returns range bound formula, dates use YYYY-MM-DD and times HH:MM[:SS] layouts
+func validationFormula(validationType excelize.DataValidationType, value string) (string, error) {
+	if value == "" {
+		return "", nil
+	}
+	switch validationType {
+	case excelize.DataValidationTypeDate:
+		ts, err := time.Parse("2006-01-02", value)
+		if err != nil {
+			return "", fmt.Errorf("invalid date: %v, %w", value, err)
+		}
+		return fmt.Sprintf("DATE(%d,%d,%d)", ts.Year(), ts.Month(), ts.Day()), nil
+	case excelize.DataValidationTypeTime:
+		layout := "15:04"
+		if strings.Count(value, ":") == 2 {
+			layout = "15:04:05"
+		}
+		ts, err := time.Parse(layout, value)
+		if err != nil {
+			return "", fmt.Errorf("invalid time: %v, %w", value, err)
+		}
+		return fmt.Sprintf("TIME(%d,%d,%d)", ts.Hour(), ts.Minute(), ts.Second()), nil
+	case excelize.DataValidationTypeDecimal:
+		if _, err := strconv.ParseFloat(value, 64); err != nil {
+			return "", fmt.Errorf("invalid decimal: %v, %w", value, err)
+		}
+		return value, nil
+	}
+	if _, err := strconv.Atoi(value); err != nil {
+		return "", fmt.Errorf("invalid whole number: %v, %w", value, err)
+	}
+	return value, nil
+}
+
+// initValidation parses column validation tag, list defaults to Enumerator values and range type to field type
+func (c *Column) initValidation() error {
+	values := c.enumerate()
+	if c.Tag.Validation == "" && len(values) == 0 {
+		return nil
+	}
+	ret, err := parseValidation(c.Tag.Validation)
+	if err != nil {
+		return err
+	}
+	if len(ret.List) == 0 && ret.Min == "" && ret.Max == "" && ret.MinLength == "" && ret.MaxLength == "" {
+		ret.List = values
+	}
+	if (ret.Min != "" || ret.Max != "") && ret.Type == "" {
+		if ret.Type = c.validationType(); ret.Type == "" {
+			return fmt.Errorf("invalid validation: %v, min/max requires numeric or time column: %v", c.Tag.Validation, c.Name)
+		}
+	}
+	if _, err = ret.dataValidation("A1"); err != nil {
+		return fmt.Errorf("invalid validation: %v, %w", c.Tag.Validation, err)
+	}
+	c.validation = ret
+	return nil
+}
+
+// validationType returns range validation type inferred from column field type
+func (c *Column) validationType() string {
+	fieldType := c.Field.Type
+	if fieldType.Kind() == reflect.Ptr {
+		fieldType = fieldType.Elem()
+	}
+	if fieldType == xreflect.TimeType {
+		return "date"
+	}
+	switch fieldType.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "whole"
+	case reflect.Float32, reflect.Float64:
+		return "decimal"
+	}
+	return ""
+}
+
+// enumerate returns allowed values if column field type implements Enumerator
+func (c *Column) enumerate() []string {
+	fieldType := c.Field.Type
+	if fieldType.Kind() == reflect.Ptr {
+		fieldType = fieldType.Elem()
+	}
+	if !reflect.PtrTo(fieldType).Implements(enumeratorType) {
+		return nil
+	}
+	return reflect.New(fieldType).Interface().(Enumerator).Enumerate()
+}
+
+// applyValidations adds columns data validations from the table data begin to the last sheet row (column for inverted table),
+// so that rows entered after the written ones are validated too; nested tables with the same orientation are included
+func (s *workSheet) applyValidations(table *Table, begin Cursor) error {
+	end := begin.clone()
+	if table.Invert() {
+		end.setColumn(excelize.MaxColumns - 1)
+	} else {
+		end.setRow(excelize.TotalRows - 1)
+	}
+	return s.forEachDataRange(table, begin, end, func(column *Column, rangeRef string) error {
+		if column.validation == nil {
+			return nil
+		}
+		dv, err := column.validation.dataValidation(rangeRef)
+		if err != nil {
+			return err
+		}
+		return s.dest.AddDataValidation(s.name, dv)
+	})
+}
@@ -0,0 +1,195 @@
+package xlsy
+
+import (
+	"bytes"
+	"github.com/stretchr/testify/assert"
+	"github.com/xuri/excelize/v2"
+	"testing"
+	"time"
+)
+
+type validationStatus string
+
+func (s validationStatus) Enumerate() []string {
+	return []string{"Open", "Closed", "Pending"}
+}
+
+func TestParseValidation(t *testing.T) {
+	var testCases = []struct {
+		description string
+		definition  string
+		expect      *validation
+		hasError    bool
+	}{
+		{
+			description: "list with error message",
+			definition:  "list:Open, Closed,Pending;error:'Pick a status';error-title:Status",
+			expect: &validation{List: []string{"Open", "Closed", "Pending"}, Error: "Pick a status", ErrorTitle: "Status",
+				ErrorStyle: excelize.DataValidationErrorStyleStop, AllowBlank: true},
+		},
+		{
+			description: "range with prompt",
+			definition:  "min:1;max:10;type:decimal;prompt:1 to 10;error-style:warning;allow-blank:false",
+			expect:      &validation{Min: "1", Max: "10", Type: "decimal", Prompt: "1 to 10", ErrorStyle: excelize.DataValidationErrorStyleWarning},
+		},
+		{
+			description: "text length",
+			definition:  "max-length:20",
+			expect:      &validation{MaxLength: "20", ErrorStyle: excelize.DataValidationErrorStyleStop, AllowBlank: true},
+		},
+		{
+			description: "list and range",
+			definition:  "list:a,b;min:1",
+			hasError:    true,
+		},
+		{
+			description: "unsupported key",
+			definition:  "regex:[a-z]+",
+			hasError:    true,
+		},
+		{
+			description: "unsupported error style",
+			definition:  "list:a;error-style:fatal",
+			hasError:    true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		actual, err := parseValidation(testCase.definition)
+		if testCase.hasError {
+			assert.NotNil(t, err, testCase.description)
+			continue
+		}
+		if !assert.Nil(t, err, testCase.description) {
+			continue
+		}
+		assert.EqualValues(t, testCase.expect, actual, testCase.description)
+	}
+}
+
+func TestMarshaller_Marshal_validation(t *testing.T) {
+	type Ticket struct {
+		ID       int              `xls:"name=Id,validation={min:1;error:'Id must be positive'}"`
+		Status   validationStatus `xls:"validation={error-title:Status;error:'Pick a status'}"`
+		Priority string           `xls:"validation={list:Low,High;prompt:Select priority}"`
+		Title    string           `xls:"validation={min-length:1;max-length:20}"`
+		Due      time.Time        `xls:"validation={min:2024-01-01;max:2024-12-31}"`
+		Score    *float64         `xls:"validation={max:0.5}"`
+	}
+	type Line struct {
+		Seq    int
+		Status validationStatus
+	}
+	type Order struct {
+		ID    int
+		Lines []Line
+	}
+	due := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	type rule struct {
+		validationType string
+		operator       string
+		formula        string
+		err            string
+		prompt         string
+	}
+	var testCases = []struct {
+		description string
+		options     []Option
+		source      interface{}
+		expect      map[string]rule
+		hasError    bool
+	}{
+		{
+			description: "column data ranges",
+			source:      []Ticket{{ID: 1, Status: "Open", Priority: "Low", Title: "a", Due: due}, {ID: 2, Status: "Closed", Title: "b", Due: due}},
+			expect: map[string]rule{
+				"A2:A1048576": {validationType: "whole", operator: "greaterThanOrEqual", formula: "<formula1>1</formula1>", err: "Id must be positive"},
+				"B2:B1048576": {validationType: "list", formula: `<formula1>"Open,Closed,Pending"</formula1>`, err: "Pick a status"},
+				"C2:C1048576": {validationType: "list", formula: `<formula1>"Low,High"</formula1>`, prompt: "Select priority"},
+				"D2:D1048576": {validationType: "textLength", operator: "between", formula: "<formula1>1</formula1><formula2>20</formula2>"},
+				"E2:E1048576": {validationType: "date", operator: "between", formula: "<formula1>DATE(2024,1,1)</formula1><formula2>DATE(2024,12,31)</formula2>"},
+				"F2:F1048576": {validationType: "decimal", operator: "lessThanOrEqual", formula: "<formula1>0.5</formula1>"},
+			},
+		},
+		{
+			description: "nested table enumerator",
+			source:      []Order{{ID: 1, Lines: []Line{{Seq: 1, Status: "Open"}, {Seq: 2, Status: "Closed"}}}, {ID: 2, Lines: []Line{{Seq: 1}}}},
+			expect: map[string]rule{
+				"C3:C1048576": {validationType: "list", formula: `<formula1>"Open,Closed,Pending"</formula1>`},
+			},
+		},
+		{
+			description: "empty table",
+			source:      []Line{},
+			expect: map[string]rule{
+				"B2:B1048576": {validationType: "list", formula: `<formula1>"Open,Closed,Pending"</formula1>`},
+			},
+		},
+		{
+			description: "inverted table",
+			options:     []Option{WithInverted()},
+			source:      []Line{{Seq: 1, Status: "Open"}},
+			expect: map[string]rule{
+				"B2:XFD2": {validationType: "list", formula: `<formula1>"Open,Closed,Pending"</formula1>`},
+			},
+		},
+		{
+			description: "streamed table",
+			options:     []Option{WithStreaming()},
+			source:      []Line{{Seq: 1, Status: "Open"}},
+			hasError:    true,
+		},
+		{
+			description: "range on text column",
+			source: []struct {
+				Name string `xls:"validation={min:1}"`
+			}{{Name: "a"}},
+			hasError: true,
+		},
+		{
+			description: "invalid date",
+			source: []struct {
+				Due time.Time `xls:"validation={min:tomorrow}"`
+			}{{Due: due}},
+			hasError: true,
+		},
+		{
+			description: "missing criteria",
+			source: []struct {
+				Name string `xls:"validation={error:required}"`
+			}{{Name: "a"}},
+			hasError: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		data, err := NewMarshaller(testCase.options...).Marshal(testCase.source)
+		if testCase.hasError {
+			assert.NotNil(t, err, testCase.description)
+			continue
+		}
+		if !assert.Nil(t, err, testCase.description) {
+			continue
+		}
+		file, err := excelize.OpenReader(bytes.NewReader(data))
+		if !assert.Nil(t, err, testCase.description) {
+			continue
+		}
+		validations, err := file.GetDataValidations(file.GetSheetName(0))
+		assert.Nil(t, err, testCase.description)
+		actual := map[string]rule{}
+		for _, dv := range validations {
+			item := rule{validationType: dv.Type, operator: dv.Operator, formula: dv.Formula1 + dv.Formula2}
+			if dv.Error != nil {
+				item.err = *dv.Error
+			}
+			if dv.Prompt != nil {
+				item.prompt = *dv.Prompt
+			}
+			actual[dv.Sqref] = item
+		}
+		assert.EqualValues(t, testCase.expect, actual, testCase.description)
+		_ = file.Close()
+	}
+}
@@ -0,0 +1,138 @@
+package xlsy
+
+import (
+	"fmt"
+	"golang.org/x/text/width"
+	"strings"
+)
+
+const (
+	autoWidthPadding = 2.0   //cell padding in characters
+	maxColumnWidth   = 255.0 //excel column width limit
+	boldWidthRatio   = 1.1
+)
+
+// autoWidth returns true if column width fits its content, explicit width takes precedence over table auto width mode
+func (c *Column) autoWidth(stylizer *Stylizer) bool {
+	for _, styleTag := range []*StyleTag{c.Tag.ColumnStyle, c.Tag.CellStyle} {
+		if styleTag == nil || styleTag.Style == "" {
+			continue
+		}
+		style := stylizer.Style(styleTag.Style)
+		if style == nil {
+			continue
+		}
+		if style.AutoWidth {
+			return true
+		}
+		if style.Column != nil && style.Column.Width != nil {
+			return false
+		}
+	}
+	return c.Tag.AutoWidth != nil && *c.Tag.AutoWidth
+}
+
+// checkAutoWidth returns an error for streamed table with auto width column, column widths precede streamed rows
+func (t *Table) checkAutoWidth() error {
+	for _, column := range t.Columns {
+		if column.Tag.Ignore || !column.autoWidth(t.Stylizer) {
+			continue
+		}
+		return fmt.Errorf("unsupported auto width for streamed table: %v, column: %v", t.SheetName(), column.Name)
+	}
+	return nil
+}
+
+// maxWidth returns column width-max or nil
+func (c *Column) maxWidth(stylizer *Stylizer) *Length {
+	for _, styleTag := range []*StyleTag{c.Tag.ColumnStyle, c.Tag.CellStyle} {
+		if styleTag == nil || styleTag.Style == "" {
+			continue
+		}
+		if style := stylizer.Style(styleTag.Style); style != nil && style.Column != nil && style.Column.WidthMax != nil {
+			return style.Column.WidthMax
+		}
+	}
+	return nil
+}
+
+// fontRatio returns header or cell font width ratio relative to the default font
+func (c *Column) fontRatio(stylizer *Stylizer, header bool) float64 {
+	styleTag := c.Tag.CellStyle
+	if header {
+		styleTag = c.Tag.HeaderStyle
+	}
+	if styleTag == nil || styleTag.Style == "" {
+		return 1
+	}
+	style := stylizer.Style(styleTag.Style)
+	if style == nil {
+		return 1
+	}
+	ext := style.Cell
+	if header {
+		ext = style.Header
+	}
+	if ext == nil || ext.Style == nil || ext.Font == nil {
+		return 1
+	}
+	font := ext.Font
+	ratio := 1.0
+	if font.Size > 0 {
+		ratio = font.Size / defaultFontSize
+	}
+	if font.Bold {
+		ratio *= boldWidthRatio
+	}
+	return ratio
+}
+
+// measure records rendered text width of auto width column cell, width is clamped by column width-max
+func (s *workSheet) measure(stylizer *Stylizer, column *Column, cell Cursor, text string, header bool) {
+	if column.Table != nil || !column.autoWidth(stylizer) {
+		return
+	}
+	width := textWidth(text)*column.fontRatio(stylizer, header) + autoWidthPadding
+	if maxWidth := column.maxWidth(stylizer); maxWidth != nil && maxWidth.Value() < width {
+		width = maxWidth.Value()
+	}
+	if width > maxColumnWidth {
+		width = maxColumnWidth
+	}
+	if s.widths == nil {
+		s.widths = make(map[int]float64)
+	}
+	if width > s.widths[cell.column()] {
+		s.widths[cell.column()] = width
+	}
+}
+
+// fitColumns sets measured auto width columns widths
+func (s *workSheet) fitColumns() error {
+	for column, width := range s.widths {
+		name := numberToColumn(column)
+		if err := s.SetColWidth(name, name, width); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// textWidth returns the longest text line width in characters, east asian wide and fullwidth characters count double
+func textWidth(text string) float64 {
+	ret := 0
+	for _, line := range strings.Split(text, "\n") {
+		lineWidth := 0
+		for _, r := range line {
+			lineWidth++
+			switch width.LookupRune(r).Kind() {
+			case width.EastAsianWide, width.EastAsianFullwidth:
+				lineWidth++
+			}
+		}
+		if lineWidth > ret {
+			ret = lineWidth
+		}
+	}
+	return float64(ret)
+}
@@ -0,0 +1,113 @@
+package xlsy
+
+import (
+	"bytes"
+	"github.com/stretchr/testify/assert"
+	"github.com/xuri/excelize/v2"
+	"testing"
+)
+
+func TestTextWidth(t *testing.T) {
+	var testCases = []struct {
+		description string
+		text        string
+		expect      float64
+	}{
+		{description: "empty", text: "", expect: 0},
+		{description: "ascii", text: "Amount", expect: 6},
+		{description: "multiline", text: "ab\nabcd\nabc", expect: 4},
+		{description: "wide characters", text: "日本", expect: 4},
+		{description: "fullwidth characters", text: "ＡＢ", expect: 4},
+		{description: "narrow three byte characters", text: "€ ｱ", expect: 3},
+	}
+
+	for _, testCase := range testCases {
+		assert.EqualValues(t, testCase.expect, textWidth(testCase.text), testCase.description)
+	}
+}
+
+func TestMarshaller_Marshal_autoWidth(t *testing.T) {
+	type Record struct {
+		ID     int     `xls:"name=Id"`
+		Name   string  `xls:"style={width-max:60px}"`
+		Amount float64 `xls:"style={format:usd}"`
+		Note   string  `xls:"style={width:30px}"`
+	}
+	type Item struct {
+		Code  string `xls:"name=C"`
+		Label string `xls:"style={font-size:22}"`
+	}
+	type Order struct {
+		No    int `xls:"name=N,style={width:auto}"`
+		Items []Item
+	}
+	type Tagged struct {
+		Orders []Order `xls:"autoWidth=true"`
+	}
+	records := []Record{{ID: 1, Name: "a very long record name", Amount: 1234567.5, Note: "note"}, {ID: 20000, Name: "b", Amount: 1}}
+	orders := []Order{{No: 1, Items: []Item{{Code: "abcdefgh", Label: "abcd"}}}}
+
+	var testCases = []struct {
+		description string
+		options     []Option
+		source      interface{}
+		expect      map[string]float64
+		hasError    bool
+	}{
+		{
+			description: "default width",
+			source:      records,
+			expect:      map[string]float64{"A": 9.140625, "B": 9.140625, "C": 9.140625, "D": 5},
+		},
+		{
+			description: "auto width option",
+			options:     []Option{WithAutoWidth()},
+			source:      records,
+			expect:      map[string]float64{"A": 7, "B": 10, "C": 15, "D": 5},
+		},
+		{
+			description: "width auto style with nested table",
+			source:      orders,
+			expect:      map[string]float64{"A": 3.1, "B": 9.140625, "C": 9.140625},
+		},
+		{
+			description: "auto width tag with nested table",
+			source:      &Tagged{Orders: orders},
+			expect:      map[string]float64{"A": 3.1, "B": 10, "C": 10},
+		},
+		{
+			description: "streamed auto width",
+			options:     []Option{WithAutoWidth(), WithStreaming()},
+			source:      records,
+			hasError:    true,
+		},
+		{
+			description: "streamed nested table auto width",
+			options:     []Option{WithStreaming()},
+			source:      &Tagged{Orders: orders},
+			expect:      map[string]float64{"A": 3.1, "B": 10, "C": 10},
+		},
+	}
+
+	for _, testCase := range testCases {
+		data, err := NewMarshaller(testCase.options...).Marshal(testCase.source)
+		if testCase.hasError {
+			assert.NotNil(t, err, testCase.description)
+			continue
+		}
+		if !assert.Nil(t, err, testCase.description) {
+			continue
+		}
+		file, err := excelize.OpenReader(bytes.NewReader(data))
+		if !assert.Nil(t, err, testCase.description) {
+			continue
+		}
+		sheet := file.GetSheetName(0)
+		for column, expect := range testCase.expect {
+			width, err := file.GetColWidth(sheet, column)
+			assert.Nil(t, err, testCase.description)
+			assert.InDelta(t, expect, width, 0.01, testCase.description+" "+column)
+		}
+		_ = file.Close()
+	}
+}